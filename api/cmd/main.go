@@ -1,19 +1,59 @@
 package main
 
 import (
+	"context"
 	"hopfield-assignment-api/internal/handlers"
+	"hopfield-assignment-api/internal/jobs"
+	"hopfield-assignment-api/pkg/config"
 	"hopfield-assignment-api/pkg/middleware"
+	"hopfield-assignment-api/pkg/middleware/auth"
+	"net/http"
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
+// buildAuthChain assembles the authenticators enabled by cfg.AuthMode
+// (AUTH_MODE, e.g. "apikey", "jwt"/"oidc", or a comma-separated
+// combination such as "apikey,oidc"). Each enabled kind is further gated
+// on the environment it needs to actually operate: Basic auth still
+// requires BASIC_AUTH_FILE to point at a user file, and OIDC still
+// requires the issuer/JWKS URLs to be set.
+func buildAuthChain(cfg *config.Config, logger *logrus.Logger) *auth.Chain {
+	modes := cfg.AuthModes()
+	var authenticators []auth.Authenticator
+
+	if modes["apikey"] {
+		authenticators = append(authenticators, auth.NewAPIKeyAuthenticatorFromEnv())
+	}
+
+	if userFile := os.Getenv("BASIC_AUTH_FILE"); modes["basic"] && userFile != "" {
+		basicAuth, err := auth.LoadUserFile(userFile)
+		if err != nil {
+			logger.WithError(err).Warn("Could not load BASIC_AUTH_FILE, Basic auth disabled")
+		} else {
+			authenticators = append(authenticators, basicAuth)
+		}
+	}
+
+	if issuer, jwksURL := os.Getenv("OIDC_ISSUER_URL"), os.Getenv("OIDC_JWKS_URL"); modes["oidc"] && issuer != "" && jwksURL != "" {
+		authenticators = append(authenticators, auth.NewOIDCAuthenticator(issuer, os.Getenv("OIDC_AUDIENCE"), jwksURL))
+	}
+
+	return auth.NewChain(logger, authenticators...)
+}
+
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid configuration")
+	}
+
 	// Configure logger
-	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{})
-	logger.SetLevel(logrus.InfoLevel)
+	logger := cfg.Logger()
 
 	// Configure Gin
 	if os.Getenv("GIN_MODE") == "release" {
@@ -23,25 +63,54 @@ func main() {
 	// Create router
 	router := gin.New()
 
+	// Metrics
+	metrics := middleware.NewMetrics(prometheus.DefaultRegisterer)
+
 	// Middleware
 	router.Use(middleware.CORS())
-	router.Use(middleware.LoggingMiddleware(logger))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.ClientCertSubject())
+	router.Use(middleware.StructuredLogging(logger))
+	router.Use(metrics.Middleware())
 	router.Use(gin.Recovery())
 
 	// Create handlers
-	healthHandler := handlers.NewHealthHandler(logger)
-	assignmentHandler := handlers.NewAssignmentHandler(logger)
+	assignmentHandler := handlers.NewAssignmentHandler(logger, cfg, metrics)
+	healthHandler := handlers.NewHealthHandler(logger, assignmentHandler.HopfieldBreaker())
+
+	// Async job subsystem
+	jobStore := jobs.NewMemoryStore()
+	jobPool := jobs.NewPool(assignmentHandler.SolveWithContext, cfg.JobPoolConcurrency, cfg.JobQueueDepth, cfg.AsyncBatchConcurrency)
+	jobPool.Start(context.Background())
+	jobsHandler := handlers.NewJobsHandler(logger, jobStore, jobPool)
+	metrics.RegisterJobQueueDepth(func() float64 { return float64(jobPool.QueueDepth()) })
+
+	// Per-route rate limiters
+	solveLimiter := middleware.NewRateLimiter(context.Background(), cfg.SolveRateLimit())
+	batchLimiter := middleware.NewRateLimiter(context.Background(), cfg.BatchRateLimit())
 
 	// Health routes
 	router.GET("/health", healthHandler.HealthCheck)
 	router.GET("/health/ready", healthHandler.ReadinessCheck)
 	router.GET("/health/live", healthHandler.LivenessCheck)
 
+	// Metrics endpoint
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// API routes
+	authChain := buildAuthChain(cfg, logger)
 	api := router.Group("/api/v1")
+	api.Use(authChain.Middleware())
 	{
-		api.POST("/solve", assignmentHandler.SolveAssignment)
-		api.POST("/solve/batch", assignmentHandler.SolveBatch)
+		api.POST("/solve", auth.RequireScope("solve:write"), solveLimiter.Middleware(), assignmentHandler.SolveAssignment)
+		api.POST("/solve/batch", auth.RequireScope("solve:write"), batchLimiter.Middleware(), assignmentHandler.SolveBatch)
+		api.POST("/solve/batch/stream", auth.RequireScope("solve:write"), batchLimiter.Middleware(), assignmentHandler.SolveBatchStream)
+		api.POST("/solve/batch/async", auth.RequireScope("solve:write"), batchLimiter.Middleware(), jobsHandler.CreateBatchJob)
+
+		api.POST("/jobs", auth.RequireScope("solve:write"), jobsHandler.CreateJob)
+		api.GET("/jobs", auth.RequireScope("solve:write"), jobsHandler.ListJobs)
+		api.GET("/jobs/:id", auth.RequireScope("solve:write"), jobsHandler.GetJob)
+		api.DELETE("/jobs/:id", auth.RequireScope("solve:write"), jobsHandler.CancelJob)
 	}
 
 	// Root route
@@ -53,14 +122,42 @@ func main() {
 		})
 	})
 
-	// Get port
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
+	runServer(router, cfg, logger)
+}
+
+// runServer starts the router on cfg.Port, choosing plain HTTP, TLS or
+// mTLS based on the TLS_* environment configuration: mTLS requires a
+// custom *http.Server so a tls.Config with ClientAuth can be supplied,
+// while plain TLS can use Gin's router.RunTLS directly.
+func runServer(router *gin.Engine, cfg *config.Config, logger *logrus.Logger) {
+	switch {
+	case cfg.TLSClientCAFile != "" && cfg.TLSEnabled():
+		tlsCfg, err := cfg.ServerTLSConfig().Build()
+		if err != nil {
+			logger.WithError(err).Fatal("Invalid TLS configuration")
+		}
+
+		srv := &http.Server{
+			Addr:      ":" + cfg.Port,
+			Handler:   router,
+			TLSConfig: tlsCfg,
+		}
+
+		logger.WithField("port", cfg.Port).Info("Starting server with mTLS")
+		if err := srv.ListenAndServeTLS("", ""); err != nil {
+			logger.WithError(err).Fatal("Error starting server")
+		}
+
+	case cfg.TLSEnabled():
+		logger.WithField("port", cfg.Port).Info("Starting server with TLS")
+		if err := router.RunTLS(":"+cfg.Port, cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+			logger.WithError(err).Fatal("Error starting server")
+		}
 
-	logger.WithField("port", port).Info("Starting server")
-	if err := router.Run(":" + port); err != nil {
-		logger.WithError(err).Fatal("Error starting server")
+	default:
+		logger.WithField("port", cfg.Port).Info("Starting server")
+		if err := router.Run(":" + cfg.Port); err != nil {
+			logger.WithError(err).Fatal("Error starting server")
+		}
 	}
 }
@@ -1,40 +1,37 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"hopfield-assignment-api/internal/models"
-	"io"
+	"errors"
 	"net/http"
-	"os"
 	"time"
 
+	"hopfield-assignment-api/internal/hopfield"
+	"hopfield-assignment-api/internal/models"
+	"hopfield-assignment-api/pkg/config"
+	"hopfield-assignment-api/pkg/middleware"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
 // AssignmentHandler handles requests related to the assignment problem
 type AssignmentHandler struct {
-	logger     *logrus.Logger
-	hopfieldURL string
-	httpClient *http.Client
+	logger                 *logrus.Logger
+	hopfieldClient         *hopfield.Client
+	metrics                *middleware.Metrics
+	batchStreamConcurrency int
+	requestTimeout         time.Duration
 }
 
 // NewAssignmentHandler creates a new instance of the handler
-func NewAssignmentHandler(logger *logrus.Logger) *AssignmentHandler {
-	hopfieldURL := os.Getenv("HOPFIELD_SERVICE_URL")
-	if hopfieldURL == "" {
-		hopfieldURL = "http://hopfield-service:5000"
-	}
-
+func NewAssignmentHandler(logger *logrus.Logger, cfg *config.Config, metrics *middleware.Metrics) *AssignmentHandler {
 	return &AssignmentHandler{
-		logger:       logger,
-		hopfieldURL:  hopfieldURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		logger:                 logger,
+		hopfieldClient:         hopfield.NewClient(logger, cfg, metrics),
+		metrics:                metrics,
+		batchStreamConcurrency: cfg.BatchStreamConcurrency,
+		requestTimeout:         cfg.HopfieldTimeout,
 	}
 }
 
@@ -61,19 +58,21 @@ func (h *AssignmentHandler) SolveAssignment(c *gin.Context) {
 	}
 
 	// Call the Hopfield service with context for timeout control
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.requestTimeout)
 	defer cancel()
 
 	result, err := h.callHopfieldServiceWithContext(ctx, req)
 	if err != nil {
 		h.logger.WithError(err).Error("Error calling Hopfield service")
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+		h.metrics.ObserveSolveRequest("solve", "error", len(req.CostMatrix))
+		c.JSON(hopfieldErrorStatus(err), models.APIResponse{
 			Success: false,
-			Error:   "Internal server error: " + err.Error(),
+			Error:   hopfieldErrorMessage(err),
 		})
 		return
 	}
 
+	h.metrics.ObserveSolveRequest("solve", "success", len(req.CostMatrix))
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Result:  result,
@@ -118,19 +117,25 @@ func (h *AssignmentHandler) SolveBatch(c *gin.Context) {
 		}
 
 		// Solve the problem with timeout
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), h.requestTimeout)
 		defer cancel()
 
 		result, err := h.callHopfieldServiceWithContext(ctx, assignmentReq)
 		if err != nil {
+			errMsg := err.Error()
+			if errors.Is(err, hopfield.ErrBreakerOpen) {
+				errMsg = "upstream unavailable"
+			}
+			h.metrics.ObserveSolveRequest("solve_batch", "error", len(problem.CostMatrix))
 			results = append(results, models.BatchResult{
 				ID:      problem.ID,
 				Success: false,
-				Error:   err.Error(),
+				Error:   errMsg,
 			})
 			continue
 		}
 
+		h.metrics.ObserveSolveRequest("solve_batch", "success", len(problem.CostMatrix))
 		results = append(results, models.BatchResult{
 			ID:      problem.ID,
 			Success: true,
@@ -144,64 +149,44 @@ func (h *AssignmentHandler) SolveBatch(c *gin.Context) {
 	})
 }
 
-// callHopfieldServiceWithContext calls the Python service to solve the problem with context
-func (h *AssignmentHandler) callHopfieldServiceWithContext(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
-	// Prepare the request
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing request: %w", err)
-	}
-
-	// Create the HTTP request with context
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", h.hopfieldURL+"/solve", bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating HTTP request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Make the request
-	resp, err := h.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response: %w", err)
-	}
-
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		var errorResp models.APIResponse
-		if err := json.Unmarshal(respBody, &errorResp); err != nil {
-			return nil, fmt.Errorf("service error (code %d): %s", resp.StatusCode, string(respBody))
-		}
-		return nil, fmt.Errorf("service error: %s", errorResp.Error)
-	}
-
-	// Parse successful response
-	var apiResp models.APIResponse
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
-	}
+// SolveWithContext solves a single assignment problem against the
+// upstream Hopfield service. It is exported so other subsystems (e.g. the
+// async job pool) can reuse the same upstream call path as the
+// synchronous handlers.
+func (h *AssignmentHandler) SolveWithContext(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+	return h.callHopfieldServiceWithContext(ctx, req)
+}
 
-	if !apiResp.Success {
-		return nil, fmt.Errorf("service error: %s", apiResp.Error)
-	}
+// callHopfieldServiceWithContext calls the Hopfield service through the
+// resilient hopfield.Client, which applies the circuit breaker, retries
+// and connection pooling.
+func (h *AssignmentHandler) callHopfieldServiceWithContext(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+	return h.hopfieldClient.Solve(ctx, req)
+}
 
-	// Convert result to AssignmentResponse
-	resultBytes, err := json.Marshal(apiResp.Result)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing result: %w", err)
+// hopfieldErrorStatus maps an error from the Hopfield client to an HTTP
+// status: a 503 when the circuit breaker is open, 500 otherwise.
+func hopfieldErrorStatus(err error) int {
+	if errors.Is(err, hopfield.ErrBreakerOpen) {
+		return http.StatusServiceUnavailable
 	}
+	return http.StatusInternalServerError
+}
 
-	var assignmentResp models.AssignmentResponse
-	if err := json.Unmarshal(resultBytes, &assignmentResp); err != nil {
-		return nil, fmt.Errorf("error parsing result: %w", err)
+// hopfieldErrorMessage maps an error from the Hopfield client to the
+// message returned to the caller. A breaker-open error hides the
+// underlying gobreaker detail behind a stable "upstream unavailable" so
+// clients can match on it regardless of breaker internals.
+func hopfieldErrorMessage(err error) string {
+	if errors.Is(err, hopfield.ErrBreakerOpen) {
+		return "upstream unavailable"
 	}
+	return "Internal server error: " + err.Error()
+}
 
-	return &assignmentResp, nil
+// HopfieldBreaker exposes the circuit breaker protecting calls to the
+// upstream Hopfield service, so the health handler can report the
+// service as unready while the breaker is open.
+func (h *AssignmentHandler) HopfieldBreaker() BreakerStateProvider {
+	return h.hopfieldClient
 }
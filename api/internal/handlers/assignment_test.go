@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
-	"hopfield-assignment-api/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"hopfield-assignment-api/internal/hopfield"
+	"hopfield-assignment-api/internal/models"
+	"hopfield-assignment-api/pkg/config"
+	"hopfield-assignment-api/pkg/middleware"
 )
 
 // MockHTTPClient es un mock del cliente HTTP
@@ -23,7 +28,8 @@ type MockHTTPClient struct {
 
 func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	args := m.Called(req)
-	return args.Get(0).(*http.Response), args.Error(1)
+	resp, _ := args.Get(0).(*http.Response)
+	return resp, args.Error(1)
 }
 
 func setupTestRouter() *gin.Engine {
@@ -78,11 +84,11 @@ func TestAssignmentHandler_SolveAssignment(t *testing.T) {
 			// Setup
 			router := setupTestRouter()
 			logger := logrus.New()
-			handler := NewAssignmentHandler(logger)
+			handler := NewAssignmentHandler(logger, testConfig(), middleware.NewMetrics(prometheus.NewRegistry()))
 
 			// Mock HTTP client
 			mockClient := &MockHTTPClient{}
-			handler.httpClient = mockClient
+			handler.hopfieldClient.SetHTTPClient(mockClient)
 
 			// Setup mock response for valid requests
 			if !tt.expectError {
@@ -192,11 +198,11 @@ func TestAssignmentHandler_SolveBatch(t *testing.T) {
 			// Setup
 			router := setupTestRouter()
 			logger := logrus.New()
-			handler := NewAssignmentHandler(logger)
+			handler := NewAssignmentHandler(logger, testConfig(), middleware.NewMetrics(prometheus.NewRegistry()))
 
 			// Mock HTTP client
 			mockClient := &MockHTTPClient{}
-			handler.httpClient = mockClient
+			handler.hopfieldClient.SetHTTPClient(mockClient)
 
 			// Setup mock response for valid requests
 			if !tt.expectError {
@@ -204,7 +210,10 @@ func TestAssignmentHandler_SolveBatch(t *testing.T) {
 					StatusCode: http.StatusOK,
 					Body:       createMockResponseBody(t, true, createMockAssignmentResponse()),
 				}
-				mockClient.On("Do", mock.Anything).Return(mockResponse, nil)
+				// .Maybe(): a batch problem that fails per-problem
+				// validation (e.g. the non-square matrix case) never
+				// reaches the Hopfield client at all.
+				mockClient.On("Do", mock.Anything).Return(mockResponse, nil).Maybe()
 			}
 
 			// Setup route
@@ -306,11 +315,11 @@ func TestAssignmentHandler_CallHopfieldServiceWithContext(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			logger := logrus.New()
-			handler := NewAssignmentHandler(logger)
+			handler := NewAssignmentHandler(logger, testConfig(), middleware.NewMetrics(prometheus.NewRegistry()))
 
 			// Mock HTTP client
 			mockClient := &MockHTTPClient{}
-			handler.httpClient = mockClient
+			handler.hopfieldClient.SetHTTPClient(mockClient)
 
 			// Setup mock expectations
 			if tt.mockResponse != nil {
@@ -346,6 +355,38 @@ func TestAssignmentHandler_CallHopfieldServiceWithContext(t *testing.T) {
 	}
 }
 
+func TestAssignmentHandler_CallHopfieldServiceWithContext_BreakerTripsOnRepeatedFailures(t *testing.T) {
+	cfg := testConfig()
+	cfg.HopfieldBreakerMinRequests = 2
+	cfg.HopfieldBreakerFailureRatio = 0.5
+
+	logger := logrus.New()
+	handler := NewAssignmentHandler(logger, cfg, middleware.NewMetrics(prometheus.NewRegistry()))
+
+	mockClient := &MockHTTPClient{}
+	handler.hopfieldClient.SetHTTPClient(mockClient)
+	mockClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       createMockErrorResponseBody(t, "Internal server error"),
+	}, nil).Twice()
+
+	req := models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := handler.callHopfieldServiceWithContext(ctx, req)
+		assert.Error(t, err)
+	}
+
+	// The breaker should now be open; a further call must short-circuit
+	// without reaching the mocked HTTP client.
+	_, err := handler.callHopfieldServiceWithContext(ctx, req)
+	assert.ErrorIs(t, err, hopfield.ErrBreakerOpen)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "Do", 2)
+}
+
 func TestAssignmentHandler_ValidateMatrix(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -393,6 +434,30 @@ func TestAssignmentHandler_ValidateMatrix(t *testing.T) {
 
 // Helper functions
 
+func testConfig() *config.Config {
+	return &config.Config{
+		HopfieldServiceURL: "http://hopfield-service:5000",
+		HopfieldTimeout:    30 * time.Second,
+
+		BatchStreamConcurrency: 4,
+
+		// No retries in tests so mocked single-shot responses (whose
+		// io.ReadCloser body can only be drained once) aren't replayed.
+		HopfieldMaxRetries:     0,
+		HopfieldRetryBaseDelay: 10 * time.Millisecond,
+
+		HopfieldMaxIdleConnsPerHost: 10,
+		HopfieldIdleConnTimeout:     90 * time.Second,
+
+		// A high MinRequests keeps the breaker closed for the handful
+		// of calls any one test makes, even when they're all failures.
+		HopfieldBreakerFailureRatio:        0.5,
+		HopfieldBreakerMinRequests:         100,
+		HopfieldBreakerCooldown:            30 * time.Second,
+		HopfieldBreakerHalfOpenMaxRequests: 1,
+	}
+}
+
 func createMockAssignmentResponse() models.AssignmentResponse {
 	return models.AssignmentResponse{
 		Assignments: []int{1, 0},
@@ -402,22 +467,22 @@ func createMockAssignmentResponse() models.AssignmentResponse {
 	}
 }
 
-func createMockResponseBody(t *testing.T, success bool, result interface{}) *bytes.Buffer {
+func createMockResponseBody(t *testing.T, success bool, result interface{}) io.ReadCloser {
 	response := models.APIResponse{
 		Success: success,
 		Result:  result,
 	}
 	jsonData, err := json.Marshal(response)
 	assert.NoError(t, err)
-	return bytes.NewBuffer(jsonData)
+	return io.NopCloser(bytes.NewBuffer(jsonData))
 }
 
-func createMockErrorResponseBody(t *testing.T, errorMsg string) *bytes.Buffer {
+func createMockErrorResponseBody(t *testing.T, errorMsg string) io.ReadCloser {
 	response := models.APIResponse{
 		Success: false,
 		Error:   errorMsg,
 	}
 	jsonData, err := json.Marshal(response)
 	assert.NoError(t, err)
-	return bytes.NewBuffer(jsonData)
+	return io.NopCloser(bytes.NewBuffer(jsonData))
 }
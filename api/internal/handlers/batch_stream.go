@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"hopfield-assignment-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatInterval is how often a ": ping" comment is written to the SSE
+// stream to keep idle-connection-closing proxies from dropping the
+// client.
+const heartbeatInterval = 15 * time.Second
+
+// batchStreamStats is the payload of the final "done" SSE event.
+type batchStreamStats struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// SolveBatchStream handles POST /api/v1/solve/batch/stream: it solves each
+// problem in the batch concurrently (bounded by batchStreamConcurrency)
+// and streams a "data: {BatchResult}\n\n" frame as each one completes,
+// followed by a final "event: done" frame with aggregate stats. Results
+// arrive out of submission order since whichever problem finishes first
+// is streamed first.
+func (h *AssignmentHandler) SolveBatchStream(c *gin.Context) {
+	var req models.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if len(req.Problems) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "At least one problem is required in the batch",
+		})
+		return
+	}
+
+	results := make(chan models.BatchResult)
+	ctx := c.Request.Context()
+	go h.runBatch(ctx, req.Problems, results)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	stats := batchStreamStats{Total: len(req.Problems)}
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				data, _ := json.Marshal(stats)
+				w.Write([]byte("event: done\ndata: " + string(data) + "\n\n"))
+				return false
+			}
+			if result.Success {
+				stats.Succeeded++
+			} else {
+				stats.Failed++
+			}
+			data, _ := json.Marshal(result)
+			w.Write([]byte("data: " + string(data) + "\n\n"))
+			return true
+		case <-heartbeat.C:
+			w.Write([]byte(": ping\n\n"))
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// runBatch solves each problem concurrently, bounded by
+// batchStreamConcurrency, and sends each BatchResult on results as it
+// completes. It closes results once every problem has been processed or
+// ctx is canceled.
+func (h *AssignmentHandler) runBatch(ctx context.Context, problems []models.BatchProblem, results chan<- models.BatchResult) {
+	defer close(results)
+
+	concurrency := h.batchStreamConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	done := make(chan struct{})
+	defer close(done)
+
+	pending := len(problems)
+	completed := make(chan struct{}, pending)
+
+	for _, problem := range problems {
+		problem := problem
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		go func() {
+			defer func() { <-sem; completed <- struct{}{} }()
+
+			result := h.solveBatchProblem(ctx, problem)
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	for i := 0; i < pending; i++ {
+		select {
+		case <-completed:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *AssignmentHandler) solveBatchProblem(ctx context.Context, problem models.BatchProblem) models.BatchResult {
+	assignmentReq := models.AssignmentRequest{CostMatrix: problem.CostMatrix}
+	if err := assignmentReq.Validate(); err != nil {
+		return models.BatchResult{ID: problem.ID, Success: false, Error: err.Error()}
+	}
+
+	result, err := h.callHopfieldServiceWithContext(ctx, assignmentReq)
+	if err != nil {
+		return models.BatchResult{ID: problem.ID, Success: false, Error: err.Error()}
+	}
+
+	return models.BatchResult{ID: problem.ID, Success: true, Result: result}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hopfield-assignment-api/internal/models"
+	"hopfield-assignment-api/pkg/middleware"
+)
+
+// closeNotifyingRecorder adds http.CloseNotifier to httptest.ResponseRecorder,
+// which gin.Context.Stream requires of the underlying ResponseWriter but
+// which the bare recorder doesn't implement; a real http.Server's
+// ResponseWriter always does.
+type closeNotifyingRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (c *closeNotifyingRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func newStreamRecorder() *closeNotifyingRecorder {
+	return &closeNotifyingRecorder{ResponseRecorder: httptest.NewRecorder()}
+}
+
+func TestAssignmentHandler_SolveBatchStream(t *testing.T) {
+	router := setupTestRouter()
+	logger := logrus.New()
+	handler := NewAssignmentHandler(logger, testConfig(), middleware.NewMetrics(prometheus.NewRegistry()))
+	handler.batchStreamConcurrency = 2
+
+	mockClient := &MockHTTPClient{}
+	handler.hopfieldClient.SetHTTPClient(mockClient)
+	mockResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       createMockResponseBody(t, true, createMockAssignmentResponse()),
+		}
+	}
+	// Each problem is solved concurrently against its own Do() call, so
+	// each needs its own Body reader: a single shared *http.Response would
+	// have its Body drained by whichever problem reads it first, leaving
+	// the other with an empty body.
+	mockClient.On("Do", mock.Anything).Return(mockResponse(), nil).Once()
+	mockClient.On("Do", mock.Anything).Return(mockResponse(), nil).Once()
+
+	router.POST("/solve/batch/stream", handler.SolveBatchStream)
+
+	body := models.BatchRequest{
+		Problems: []models.BatchProblem{
+			{ID: "problem_1", CostMatrix: [][]float64{{1, 2}, {3, 4}}},
+			{ID: "problem_2", CostMatrix: [][]float64{{5, 6}, {7, 8}}},
+		},
+	}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/solve/batch/stream", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := newStreamRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	respBody := w.Body.String()
+	// Count only per-problem result frames; the final "event: done" frame
+	// also starts with "data: {" but carries aggregate stats, not a result.
+	assert.Equal(t, 2, strings.Count(respBody, "data: {\"id\":"))
+	assert.Contains(t, respBody, "event: done")
+	assert.Contains(t, respBody, `"total":2`)
+	assert.Contains(t, respBody, `"succeeded":2`)
+}
+
+func TestAssignmentHandler_SolveBatchStream_EmptyProblems(t *testing.T) {
+	router := setupTestRouter()
+	logger := logrus.New()
+	handler := NewAssignmentHandler(logger, testConfig(), middleware.NewMetrics(prometheus.NewRegistry()))
+
+	router.POST("/solve/batch/stream", handler.SolveBatchStream)
+
+	jsonBody, _ := json.Marshal(models.BatchRequest{Problems: []models.BatchProblem{}})
+	req := httptest.NewRequest("POST", "/solve/batch/stream", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
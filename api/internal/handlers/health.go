@@ -8,15 +8,26 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// BreakerStateProvider reports whether the circuit breaker protecting
+// calls to the upstream Hopfield service is currently open. It is
+// satisfied by *hopfield.Client.
+type BreakerStateProvider interface {
+	IsOpen() bool
+}
+
 // HealthHandler handles service health requests
 type HealthHandler struct {
-	logger *logrus.Logger
+	logger  *logrus.Logger
+	breaker BreakerStateProvider
 }
 
-// NewHealthHandler creates a new instance of the health handler
-func NewHealthHandler(logger *logrus.Logger) *HealthHandler {
+// NewHealthHandler creates a new instance of the health handler. breaker
+// may be nil, in which case ReadinessCheck never reports unavailable due
+// to the circuit breaker.
+func NewHealthHandler(logger *logrus.Logger, breaker BreakerStateProvider) *HealthHandler {
 	return &HealthHandler{
-		logger: logger,
+		logger:  logger,
+		breaker: breaker,
 	}
 }
 
@@ -31,12 +42,19 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// ReadinessCheck verifies if the service is ready to receive traffic
+// ReadinessCheck verifies if the service is ready to receive traffic. It
+// returns 503 when the circuit breaker protecting the upstream Hopfield
+// service is open, since the service cannot usefully serve solve
+// requests in that state.
 func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
-	// Here you could add additional checks such as:
-	// - Database connectivity
-	// - External service availability
-	// - System resource verification
+	if h.breaker != nil && h.breaker.IsOpen() {
+		c.JSON(http.StatusServiceUnavailable, models.HealthResponse{
+			Status:  "unavailable",
+			Service: "hopfield-assignment-api",
+			Version: "1.0.0",
+		})
+		return
+	}
 
 	response := models.HealthResponse{
 		Status:  "ready",
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"hopfield-assignment-api/internal/models"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestHealthHandler_HealthCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	logger := logrus.New()
-	handler := NewHealthHandler(logger)
+	handler := NewHealthHandler(logger, nil)
 
 	// Setup route
 	router.GET("/health", handler.HealthCheck)
@@ -38,7 +39,7 @@ func TestHealthHandler_HealthCheck(t *testing.T) {
 
 	// Parse response
 	var response models.HealthResponse
-	err := w.Body.UnmarshalJSON(w.Body.Bytes())
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 
 	// Verify response content
@@ -52,7 +53,7 @@ func TestHealthHandler_ReadinessCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	logger := logrus.New()
-	handler := NewHealthHandler(logger)
+	handler := NewHealthHandler(logger, nil)
 
 	// Setup route
 	router.GET("/health/ready", handler.ReadinessCheck)
@@ -72,7 +73,7 @@ func TestHealthHandler_ReadinessCheck(t *testing.T) {
 
 	// Parse response
 	var response models.HealthResponse
-	err := w.Body.UnmarshalJSON(w.Body.Bytes())
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 
 	// Verify response content
@@ -86,7 +87,7 @@ func TestHealthHandler_LivenessCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	logger := logrus.New()
-	handler := NewHealthHandler(logger)
+	handler := NewHealthHandler(logger, nil)
 
 	// Setup route
 	router.GET("/health/live", handler.LivenessCheck)
@@ -106,7 +107,7 @@ func TestHealthHandler_LivenessCheck(t *testing.T) {
 
 	// Parse response
 	var response models.HealthResponse
-	err := w.Body.UnmarshalJSON(w.Body.Bytes())
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 
 	// Verify response content
@@ -115,12 +116,44 @@ func TestHealthHandler_LivenessCheck(t *testing.T) {
 	assert.Equal(t, "1.0.0", response.Version)
 }
 
+type fakeBreaker struct{ open bool }
+
+func (f fakeBreaker) IsOpen() bool { return f.open }
+
+func TestHealthHandler_ReadinessCheck_BreakerOpen(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	logger := logrus.New()
+	handler := NewHealthHandler(logger, fakeBreaker{open: true})
+
+	// Setup route
+	router.GET("/health/ready", handler.ReadinessCheck)
+
+	// Create request
+	req := httptest.NewRequest("GET", "/health/ready", nil)
+
+	// Create response recorder
+	w := httptest.NewRecorder()
+
+	// Perform request
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response models.HealthResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "unavailable", response.Status)
+}
+
 func TestHealthHandler_AllEndpoints(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	logger := logrus.New()
-	handler := NewHealthHandler(logger)
+	handler := NewHealthHandler(logger, nil)
 
 	// Setup routes
 	router.GET("/health", handler.HealthCheck)
@@ -154,7 +187,7 @@ func TestHealthHandler_AllEndpoints(t *testing.T) {
 
 			// Parse response
 			var response models.HealthResponse
-			err := w.Body.UnmarshalJSON(w.Body.Bytes())
+			err := json.Unmarshal(w.Body.Bytes(), &response)
 			assert.NoError(t, err)
 
 			// Verify response content
@@ -170,7 +203,7 @@ func TestHealthHandler_ResponseFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	logger := logrus.New()
-	handler := NewHealthHandler(logger)
+	handler := NewHealthHandler(logger, nil)
 
 	// Setup route
 	router.GET("/health", handler.HealthCheck)
@@ -189,7 +222,7 @@ func TestHealthHandler_ResponseFormat(t *testing.T) {
 
 	// Verify JSON structure
 	var response map[string]interface{}
-	err := w.Body.UnmarshalJSON(w.Body.Bytes())
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 
 	// Check required fields
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"hopfield-assignment-api/internal/jobs"
+	"hopfield-assignment-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateJobRequest is the body accepted by POST /api/v1/jobs. Exactly one
+// of CostMatrix or Problems should be set: CostMatrix enqueues a single
+// job, Problems enqueues one job per entry (mirroring SolveBatch).
+type CreateJobRequest struct {
+	CostMatrix [][]float64           `json:"cost_matrix,omitempty"`
+	Problems   []models.BatchProblem `json:"problems,omitempty"`
+}
+
+// JobsHandler exposes the async job API: submitting assignment problems
+// for background processing and polling their status.
+type JobsHandler struct {
+	logger *logrus.Logger
+	store  jobs.Store
+	pool   *jobs.Pool
+}
+
+// NewJobsHandler creates a JobsHandler backed by store, dispatching work
+// to pool.
+func NewJobsHandler(logger *logrus.Logger, store jobs.Store, pool *jobs.Pool) *JobsHandler {
+	return &JobsHandler{logger: logger, store: store, pool: pool}
+}
+
+// CreateJob handles POST /api/v1/jobs.
+func (h *JobsHandler) CreateJob(c *gin.Context) {
+	var req CreateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if len(req.Problems) > 0 {
+		h.createBatch(c, req.Problems)
+		return
+	}
+
+	assignmentReq := models.AssignmentRequest{CostMatrix: req.CostMatrix}
+	if err := assignmentReq.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	job, err := h.enqueue(assignmentReq)
+	if err != nil {
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"id":         job.ID,
+		"status_url": "/api/v1/jobs/" + job.ID,
+	})
+}
+
+func (h *JobsHandler) createBatch(c *gin.Context, problems []models.BatchProblem) {
+	type submission struct {
+		ID        string `json:"id"`
+		JobID     string `json:"job_id,omitempty"`
+		StatusURL string `json:"status_url,omitempty"`
+		Error     string `json:"error,omitempty"`
+	}
+
+	submissions := make([]submission, 0, len(problems))
+	for _, problem := range problems {
+		assignmentReq := models.AssignmentRequest{CostMatrix: problem.CostMatrix}
+		if err := assignmentReq.Validate(); err != nil {
+			submissions = append(submissions, submission{ID: problem.ID, Error: err.Error()})
+			continue
+		}
+
+		job, err := h.enqueue(assignmentReq)
+		if err != nil {
+			submissions = append(submissions, submission{ID: problem.ID, Error: err.Error()})
+			continue
+		}
+		submissions = append(submissions, submission{ID: problem.ID, JobID: job.ID, StatusURL: "/api/v1/jobs/" + job.ID})
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"jobs": submissions})
+}
+
+func (h *JobsHandler) enqueue(req models.AssignmentRequest) (*jobs.Job, error) {
+	job := h.store.Create()
+	job.Request = req
+	if err := h.pool.Submit(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// CreateBatchJob handles POST /api/v1/solve/batch/async. Unlike CreateJob
+// with a Problems body (which fans out one job per problem), it tracks
+// the whole batch as a single job so a client can poll one ID for
+// aggregate progress and results.
+func (h *JobsHandler) CreateBatchJob(c *gin.Context) {
+	var req models.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	if len(req.Problems) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "At least one problem is required in the batch",
+		})
+		return
+	}
+
+	var valid []models.BatchProblem
+	var seeded []models.BatchResult
+	for _, problem := range req.Problems {
+		assignmentReq := models.AssignmentRequest{CostMatrix: problem.CostMatrix}
+		if err := assignmentReq.Validate(); err != nil {
+			seeded = append(seeded, models.BatchResult{ID: problem.ID, Success: false, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, problem)
+	}
+
+	job := h.store.Create()
+	job.Problems = valid
+	job.SeedBatch(len(req.Problems), seeded)
+
+	if err := h.pool.Submit(job); err != nil {
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"job_id":     job.ID,
+		"status_url": "/api/v1/jobs/" + job.ID,
+	})
+}
+
+// GetJob handles GET /api/v1/jobs/:id.
+func (h *JobsHandler) GetJob(c *gin.Context) {
+	job, err := h.store.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobResponse(job))
+}
+
+// ListJobs handles GET /api/v1/jobs?offset=&limit=.
+func (h *JobsHandler) ListJobs(c *gin.Context) {
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+
+	list, total := h.store.List(offset, limit)
+	responses := make([]gin.H, 0, len(list))
+	for _, job := range list {
+		responses = append(responses, jobResponse(job))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":   responses,
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+	})
+}
+
+// CancelJob handles DELETE /api/v1/jobs/:id.
+func (h *JobsHandler) CancelJob(c *gin.Context) {
+	if err := h.store.Cancel(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, models.APIResponse{Success: true})
+}
+
+func jobResponse(job *jobs.Job) gin.H {
+	view := job.View()
+	resp := gin.H{
+		"id":         view.ID,
+		"status":     view.Status,
+		"created_at": view.CreatedAt,
+	}
+	if !view.StartedAt.IsZero() {
+		resp["started_at"] = view.StartedAt
+	}
+	if !view.FinishedAt.IsZero() {
+		resp["finished_at"] = view.FinishedAt
+	}
+	if view.Result != nil {
+		resp["result"] = view.Result
+	}
+	if view.Error != "" {
+		resp["error"] = view.Error
+	}
+	if view.Progress.Total > 0 {
+		resp["progress"] = gin.H{"done": view.Progress.Done, "total": view.Progress.Total}
+		resp["results"] = view.Results
+	}
+	return resp
+}
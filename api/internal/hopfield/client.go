@@ -0,0 +1,304 @@
+// Package hopfield implements a resilient client for the upstream
+// Hopfield assignment-solving service. It wraps a connection-pooled
+// http.Client with a circuit breaker and bounded, jittered retries so a
+// wedged or overloaded upstream degrades the API gracefully instead of
+// hanging every caller.
+package hopfield
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hopfield-assignment-api/internal/models"
+	"hopfield-assignment-api/pkg/config"
+	"hopfield-assignment-api/pkg/middleware"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+)
+
+// ErrBreakerOpen is returned when the circuit breaker short-circuits a
+// call without reaching the upstream service.
+var ErrBreakerOpen = gobreaker.ErrOpenState
+
+// HTTPDoer is satisfied by *http.Client. It exists so tests can
+// substitute a mock transport via SetHTTPClient.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// nonRetryableError marks an error as permanent: the caller's input or
+// the upstream's response was malformed in a way that retrying cannot
+// fix (e.g. a 4xx response).
+type nonRetryableError struct{ err error }
+
+func (e *nonRetryableError) Error() string { return e.err.Error() }
+func (e *nonRetryableError) Unwrap() error { return e.err }
+
+func nonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &nonRetryableError{err: err}
+}
+
+func isRetryable(err error) bool {
+	var nre *nonRetryableError
+	return !errors.As(err, &nre)
+}
+
+// retryAfterError marks a retryable error (a 429 response) that carried a
+// Retry-After header, so the retry loop can honor the upstream's
+// requested delay instead of its own jittered backoff.
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110
+// is either a number of seconds or an HTTP-date. It returns false if the
+// header is absent or unparseable.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// Client calls the upstream Hopfield service through a circuit breaker,
+// retrying idempotent failures (network errors, 5xx and 429 responses)
+// with exponential backoff and full jitter, or the upstream's requested
+// Retry-After delay when one is given.
+type Client struct {
+	baseURL    string
+	httpClient HTTPDoer
+	breaker    *gobreaker.CircuitBreaker
+	maxRetries int
+	retryBase  time.Duration
+	metrics    *middleware.Metrics
+}
+
+// SetHTTPClient overrides the underlying HTTP client used for upstream
+// calls. It exists so tests can substitute a mock transport.
+func (c *Client) SetHTTPClient(doer HTTPDoer) {
+	c.httpClient = doer
+}
+
+// NewClient builds a Client from cfg: a connection-pooled http.Transport
+// (optionally with TLS/mTLS to the upstream), and a circuit breaker
+// tuned by the HopfieldBreaker* configuration fields. An invalid TLS
+// configuration is logged and the pooled transport keeps Go's default
+// TLS settings rather than failing startup.
+func NewClient(logger *logrus.Logger, cfg *config.Config, metrics *middleware.Metrics) *Client {
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: cfg.HopfieldMaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.HopfieldIdleConnTimeout,
+		ForceAttemptHTTP2:   true,
+	}
+
+	if tlsClientCfg := cfg.HopfieldTLSConfig(); !tlsClientCfg.IsZero() {
+		tlsCfg, err := tlsClientCfg.Build()
+		if err != nil {
+			logger.WithError(err).Error("Invalid Hopfield TLS configuration, falling back to default TLS settings")
+		} else {
+			transport.TLSClientConfig = tlsCfg
+		}
+	}
+
+	client := &Client{
+		baseURL: cfg.HopfieldServiceURL,
+		httpClient: &http.Client{
+			Timeout:   cfg.HopfieldTimeout,
+			Transport: transport,
+		},
+		maxRetries: cfg.HopfieldMaxRetries,
+		retryBase:  cfg.HopfieldRetryBaseDelay,
+		metrics:    metrics,
+	}
+
+	client.breaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "hopfield-service",
+		MaxRequests: cfg.HopfieldBreakerHalfOpenMaxRequests,
+		Timeout:     cfg.HopfieldBreakerCooldown,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			if counts.Requests < cfg.HopfieldBreakerMinRequests {
+				return false
+			}
+			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
+			return failureRatio >= cfg.HopfieldBreakerFailureRatio
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			if metrics != nil {
+				metrics.ObserveBreakerStateChange(from.String(), to.String())
+			}
+		},
+	})
+
+	return client
+}
+
+// IsOpen reports whether the circuit breaker is currently open, meaning
+// calls are being short-circuited without reaching the upstream.
+func (c *Client) IsOpen() bool {
+	return c.breaker.State() == gobreaker.StateOpen
+}
+
+// Solve calls the upstream Hopfield service to solve req. The call is
+// routed through the circuit breaker; network errors, 5xx responses and
+// 429 responses are retried up to maxRetries times, bounded by ctx's
+// deadline. A 429 that carries a Retry-After header is retried after
+// that delay rather than the usual jittered backoff. Other 4xx responses
+// and malformed requests are not retried.
+func (c *Client) Solve(ctx context.Context, req models.AssignmentRequest) (result *models.AssignmentResponse, err error) {
+	start := time.Now()
+	var timedOut bool
+	defer func() {
+		if c.metrics != nil {
+			c.metrics.ObserveHopfieldCall(time.Since(start), err, timedOut)
+		}
+	}()
+
+	for attempt := 0; ; attempt++ {
+		var attemptResult *models.AssignmentResponse
+		_, breakerErr := c.breaker.Execute(func() (interface{}, error) {
+			res, callErr := c.call(ctx, req)
+			attemptResult = res
+			return nil, callErr
+		})
+
+		if breakerErr == nil {
+			return attemptResult, nil
+		}
+
+		if errors.Is(breakerErr, gobreaker.ErrOpenState) || errors.Is(breakerErr, gobreaker.ErrTooManyRequests) {
+			return nil, fmt.Errorf("hopfield service unavailable: %w", breakerErr)
+		}
+
+		if !isRetryable(breakerErr) || attempt >= c.maxRetries {
+			timedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+			return nil, unwrapNonRetryable(breakerErr)
+		}
+
+		if c.metrics != nil {
+			c.metrics.ObserveHopfieldRetry()
+		}
+
+		delay := backoffWithFullJitter(c.retryBase, attempt)
+		var rae *retryAfterError
+		if errors.As(breakerErr, &rae) {
+			delay = rae.after
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			timedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func unwrapNonRetryable(err error) error {
+	var nre *nonRetryableError
+	if errors.As(err, &nre) {
+		return nre.err
+	}
+	return err
+}
+
+// backoffWithFullJitter returns a random delay in [0, base*2^attempt),
+// the "full jitter" strategy: it spreads retries out enough to avoid
+// synchronized thundering-herd retries against a recovering upstream.
+func backoffWithFullJitter(base time.Duration, attempt int) time.Duration {
+	maxDelay := base * time.Duration(uint64(1)<<uint(attempt))
+	return time.Duration(rand.Int63n(int64(maxDelay)))
+}
+
+// call performs a single HTTP round trip to the upstream service,
+// without retry or breaker logic.
+func (c *Client) call(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, nonRetryable(fmt.Errorf("error serializing request: %w", err))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/solve", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, nonRetryable(fmt.Errorf("error creating HTTP request: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := fmt.Sprintf("service error (code %d): %s", resp.StatusCode, string(respBody))
+		var errorResp models.APIResponse
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error != "" {
+			msg = fmt.Sprintf("service error: %s", errorResp.Error)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			callErr := errors.New(msg)
+			if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				return nil, &retryAfterError{err: callErr, after: after}
+			}
+			return nil, callErr
+		}
+		if resp.StatusCode >= 500 {
+			return nil, errors.New(msg)
+		}
+		return nil, nonRetryable(errors.New(msg))
+	}
+
+	var apiResp models.APIResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, nonRetryable(fmt.Errorf("error parsing response: %w", err))
+	}
+
+	if !apiResp.Success {
+		return nil, nonRetryable(fmt.Errorf("service error: %s", apiResp.Error))
+	}
+
+	resultBytes, err := json.Marshal(apiResp.Result)
+	if err != nil {
+		return nil, nonRetryable(fmt.Errorf("error serializing result: %w", err))
+	}
+
+	var assignmentResp models.AssignmentResponse
+	if err := json.Unmarshal(resultBytes, &assignmentResp); err != nil {
+		return nil, nonRetryable(fmt.Errorf("error parsing result: %w", err))
+	}
+
+	return &assignmentResp, nil
+}
@@ -0,0 +1,145 @@
+package hopfield
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"hopfield-assignment-api/internal/models"
+	"hopfield-assignment-api/pkg/config"
+)
+
+// fakeDoer is a minimal HTTPDoer whose Do delegates to a per-test
+// function, so each test can script a sequence of responses without
+// pulling in a mocking library for a single-method interface.
+type fakeDoer struct {
+	do    func(req *http.Request) (*http.Response, error)
+	calls int
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	f.calls++
+	return f.do(req)
+}
+
+func jsonBody(s string) io.ReadCloser {
+	return io.NopCloser(bytes.NewBufferString(s))
+}
+
+func testClientConfig() *config.Config {
+	return &config.Config{
+		HopfieldServiceURL: "http://hopfield-service:5000",
+		HopfieldTimeout:    5 * time.Second,
+
+		HopfieldMaxRetries:     2,
+		HopfieldRetryBaseDelay: time.Millisecond,
+
+		HopfieldMaxIdleConnsPerHost: 10,
+		HopfieldIdleConnTimeout:     90 * time.Second,
+
+		HopfieldBreakerFailureRatio:        0.5,
+		HopfieldBreakerMinRequests:         100,
+		HopfieldBreakerCooldown:            30 * time.Second,
+		HopfieldBreakerHalfOpenMaxRequests: 1,
+	}
+}
+
+func TestClient_Solve_Success(t *testing.T) {
+	client := NewClient(logrus.New(), testClientConfig(), nil)
+	client.SetHTTPClient(&fakeDoer{do: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       jsonBody(`{"success":true,"result":{"assignments":[1,0],"total_cost":5,"iterations":10}}`),
+		}, nil
+	}})
+
+	result, err := client.Solve(context.Background(), models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, result.Assignments)
+	assert.Equal(t, 5.0, result.TotalCost)
+}
+
+func TestClient_Solve_RetriesServerErrorThenSucceeds(t *testing.T) {
+	doer := &fakeDoer{}
+	doer.do = func(req *http.Request) (*http.Response, error) {
+		if doer.calls == 1 {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: jsonBody(`{"success":false,"error":"boom"}`)}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: jsonBody(`{"success":true,"result":{"assignments":[0,1],"total_cost":2,"iterations":3}}`)}, nil
+	}
+
+	client := NewClient(logrus.New(), testClientConfig(), nil)
+	client.SetHTTPClient(doer)
+
+	result, err := client.Solve(context.Background(), models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, result.Assignments)
+	assert.Equal(t, 2, doer.calls)
+}
+
+func TestClient_Solve_NonRetryableClientError(t *testing.T) {
+	doer := &fakeDoer{do: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusBadRequest, Body: jsonBody(`{"success":false,"error":"invalid matrix"}`)}, nil
+	}}
+
+	client := NewClient(logrus.New(), testClientConfig(), nil)
+	client.SetHTTPClient(doer)
+
+	_, err := client.Solve(context.Background(), models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}})
+	assert.Error(t, err)
+	assert.Equal(t, 1, doer.calls)
+}
+
+func TestClient_Solve_RetriesTooManyRequestsAfterRetryAfterHeader(t *testing.T) {
+	doer := &fakeDoer{}
+	doer.do = func(req *http.Request) (*http.Response, error) {
+		if doer.calls == 1 {
+			resp := &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       jsonBody(`{"success":false,"error":"rate limited"}`),
+			}
+			return resp, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: jsonBody(`{"success":true,"result":{"assignments":[0,1],"total_cost":2,"iterations":3}}`)}, nil
+	}
+
+	client := NewClient(logrus.New(), testClientConfig(), nil)
+	client.SetHTTPClient(doer)
+
+	result, err := client.Solve(context.Background(), models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, result.Assignments)
+	assert.Equal(t, 2, doer.calls)
+}
+
+func TestClient_Solve_BreakerOpensAfterFailures(t *testing.T) {
+	cfg := testClientConfig()
+	cfg.HopfieldMaxRetries = 0
+	cfg.HopfieldBreakerMinRequests = 2
+	cfg.HopfieldBreakerFailureRatio = 0.5
+
+	doer := &fakeDoer{do: func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}}
+
+	client := NewClient(logrus.New(), cfg, nil)
+	client.SetHTTPClient(doer)
+
+	req := models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}}
+	for i := 0; i < 2; i++ {
+		_, err := client.Solve(context.Background(), req)
+		assert.Error(t, err)
+	}
+
+	assert.True(t, client.IsOpen())
+
+	_, err := client.Solve(context.Background(), req)
+	assert.ErrorIs(t, err, ErrBreakerOpen)
+}
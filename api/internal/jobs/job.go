@@ -0,0 +1,182 @@
+// Package jobs implements an asynchronous job subsystem for assignment
+// problems that are too large to solve within a single synchronous HTTP
+// request: a Store tracks job state, and a Pool of workers pulls queued
+// jobs and solves them in the background.
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hopfield-assignment-api/internal/models"
+)
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// BatchProgress reports how many of a batch job's problems have completed
+// out of the total submitted.
+type BatchProgress struct {
+	Done  int
+	Total int
+}
+
+// Job represents work submitted for asynchronous processing: either a
+// single assignment problem (Request) or a batch of independent problems
+// tracked as one unit (Problems). The exported fields are set before the
+// job is submitted and read without locking afterwards. The unexported
+// state fields are mutated concurrently by the worker pool and read
+// concurrently by HTTP handlers, so access to them goes through the
+// locked accessors below.
+type Job struct {
+	ID        string
+	Request   models.AssignmentRequest
+	Problems  []models.BatchProblem
+	CreatedAt time.Time
+
+	mu         sync.RWMutex
+	status     Status
+	startedAt  time.Time
+	finishedAt time.Time
+	result     *models.AssignmentResponse
+	results    []models.BatchResult
+	total      int
+	isBatch    bool
+	errMsg     string
+	cancel     context.CancelFunc
+}
+
+// View is an immutable snapshot of a Job's state, safe to read after it
+// has been returned from a View() call.
+type View struct {
+	ID         string
+	Status     Status
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Request    models.AssignmentRequest
+	Result     *models.AssignmentResponse
+	Error      string
+
+	// Progress and Results are only meaningful for a batch job
+	// (Progress.Total > 0).
+	Progress BatchProgress
+	Results  []models.BatchResult
+}
+
+// View returns a consistent snapshot of the job's current state.
+func (j *Job) View() View {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return View{
+		ID:         j.ID,
+		Status:     j.status,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.startedAt,
+		FinishedAt: j.finishedAt,
+		Request:    j.Request,
+		Result:     j.result,
+		Error:      j.errMsg,
+		Progress:   BatchProgress{Done: len(j.results), Total: j.total},
+		Results:    append([]models.BatchResult(nil), j.results...),
+	}
+}
+
+// SeedBatch configures the job as a batch job tracking total problems.
+// seeded holds results already known at submission time (e.g. per-problem
+// validation failures), so they count toward progress without going
+// through the worker pool.
+func (j *Job) SeedBatch(total int, seeded []models.BatchResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.isBatch = true
+	j.total = total
+	j.results = append(j.results, seeded...)
+}
+
+// IsBatch reports whether the job is a batch job, i.e. SeedBatch has been
+// called on it. This is independent of len(Problems), which may be zero
+// for a batch whose problems all failed validation before dispatch.
+func (j *Job) IsBatch() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.isBatch
+}
+
+// recordBatchResult appends result to the job's accumulated batch results.
+func (j *Job) recordBatchResult(result models.BatchResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, result)
+}
+
+// markRunning transitions the job to running and records the cancel func
+// that Cancel() should invoke to abort the in-flight solve. It returns
+// false without changing anything if the job is not still pending, i.e.
+// it was already canceled while sitting in the queue; the caller must
+// not run a job markRunning refused to start.
+func (j *Job) markRunning(cancel context.CancelFunc) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != StatusPending {
+		return false
+	}
+	j.status = StatusRunning
+	j.startedAt = time.Now()
+	j.cancel = cancel
+	return true
+}
+
+// markDone records a successful result and transitions the job to done.
+func (j *Job) markDone(result *models.AssignmentResponse) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status = StatusDone
+	j.result = result
+	j.finishedAt = time.Now()
+}
+
+// markBatchDone transitions a batch job to done once every problem has
+// been recorded. Unlike markDone, it leaves the per-problem outcomes
+// (already accumulated via recordBatchResult) as the job's result.
+func (j *Job) markBatchDone() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status = StatusDone
+	j.finishedAt = time.Now()
+}
+
+// markFailed records an error and transitions the job to failed.
+func (j *Job) markFailed(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status = StatusFailed
+	j.errMsg = err.Error()
+	j.finishedAt = time.Now()
+}
+
+// cancelIfRunning cancels the job's context (aborting any in-flight
+// upstream call) if one has been set, and marks the job failed if it was
+// still pending or running.
+func (j *Job) cancelIfRunning() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancel != nil {
+		j.cancel()
+	}
+	if j.status == StatusPending || j.status == StatusRunning {
+		j.status = StatusFailed
+		j.errMsg = "canceled"
+		j.finishedAt = time.Now()
+	}
+}
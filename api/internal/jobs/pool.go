@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"hopfield-assignment-api/internal/models"
+)
+
+// ErrQueueFull is returned by Pool.Submit when the bounded queue has no
+// room left for another job.
+var ErrQueueFull = errors.New("job queue is full")
+
+// Solver solves a single assignment problem. It is typically backed by
+// AssignmentHandler.SolveWithContext.
+type Solver func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error)
+
+// Pool is a bounded worker pool that pulls pending jobs off a queue and
+// solves them with Solver, updating each Job's state as it goes. A batch
+// job (one on which SeedBatch was called) has its problems dispatched to Solver
+// concurrently, bounded by batchConcurrency, instead of one at a time.
+type Pool struct {
+	solve            Solver
+	concurrency      int
+	queue            chan *Job
+	batchConcurrency int
+}
+
+// NewPool creates a Pool with the given concurrency (number of workers
+// pulling jobs off the queue), maxQueueDepth (capacity of the pending-job
+// channel; Submit returns ErrQueueFull once it is reached) and
+// batchConcurrency (how many problems within a single batch job are
+// solved concurrently).
+func NewPool(solve Solver, concurrency, maxQueueDepth, batchConcurrency int) *Pool {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if maxQueueDepth <= 0 {
+		maxQueueDepth = concurrency
+	}
+	if batchConcurrency <= 0 {
+		batchConcurrency = 1
+	}
+	return &Pool{
+		solve:            solve,
+		concurrency:      concurrency,
+		queue:            make(chan *Job, maxQueueDepth),
+		batchConcurrency: batchConcurrency,
+	}
+}
+
+// Start launches the worker goroutines. They run until ctx is canceled.
+func (p *Pool) Start(ctx context.Context) {
+	for i := 0; i < p.concurrency; i++ {
+		go p.worker(ctx)
+	}
+}
+
+// Submit enqueues job for processing. It returns ErrQueueFull if the
+// queue is at capacity, so the caller can respond 429 rather than block.
+func (p *Pool) Submit(job *Job) error {
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// QueueDepth returns the number of jobs currently waiting in the queue
+// for a free worker, for exposing as a metrics gauge.
+func (p *Pool) QueueDepth() int {
+	return len(p.queue)
+}
+
+func (p *Pool) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-p.queue:
+			p.process(ctx, job)
+		}
+	}
+}
+
+func (p *Pool) process(ctx context.Context, job *Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if !job.markRunning(cancel) {
+		// Already canceled while still queued: leave its terminal state
+		// alone rather than running it anyway.
+		return
+	}
+
+	if job.IsBatch() {
+		p.processBatch(jobCtx, job)
+		return
+	}
+
+	result, err := p.solve(jobCtx, job.Request)
+	if err != nil {
+		job.markFailed(err)
+		return
+	}
+	job.markDone(result)
+}
+
+// processBatch solves every problem in job.Problems concurrently, bounded
+// by p.batchConcurrency, recording each outcome as it completes so
+// progress is visible to pollers while the batch is still running.
+func (p *Pool) processBatch(ctx context.Context, job *Job) {
+	sem := make(chan struct{}, p.batchConcurrency)
+	var wg sync.WaitGroup
+
+	for _, problem := range job.Problems {
+		problem := problem
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			req := models.AssignmentRequest{CostMatrix: problem.CostMatrix}
+			result, err := p.solve(ctx, req)
+			if err != nil {
+				job.recordBatchResult(models.BatchResult{ID: problem.ID, Success: false, Error: err.Error()})
+				return
+			}
+			job.recordBatchResult(models.BatchResult{ID: problem.ID, Success: true, Result: result})
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-ctx.Done():
+		// Canceled mid-flight: cancelIfRunning already marked the job
+		// failed, so leave its terminal state alone.
+	default:
+		job.markBatchDone()
+	}
+}
@@ -0,0 +1,280 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"hopfield-assignment-api/internal/models"
+)
+
+func TestPool_SubmitAndProcess(t *testing.T) {
+	store := NewMemoryStore()
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		return &models.AssignmentResponse{Assignments: []int{0, 1}, TotalCost: 3}, nil
+	}
+
+	pool := NewPool(solve, 2, 4, 2)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	job.Request = models.AssignmentRequest{CostMatrix: [][]float64{{1, 2}, {3, 4}}}
+	assert.NoError(t, pool.Submit(job))
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusDone
+	}, time.Second, 10*time.Millisecond)
+
+	view := job.View()
+	assert.Equal(t, StatusDone, view.Status)
+	assert.NotNil(t, view.Result)
+	assert.Equal(t, float64(3), view.Result.TotalCost)
+}
+
+func TestPool_SolveError(t *testing.T) {
+	store := NewMemoryStore()
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		return nil, errors.New("upstream failed")
+	}
+
+	pool := NewPool(solve, 1, 1, 1)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	assert.NoError(t, pool.Submit(job))
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusFailed
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "upstream failed", job.View().Error)
+}
+
+func TestPool_SubmitQueueFull(t *testing.T) {
+	block := make(chan struct{})
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		<-block
+		return &models.AssignmentResponse{}, nil
+	}
+
+	pool := NewPool(solve, 1, 1, 1)
+	pool.Start(context.Background())
+
+	store := NewMemoryStore()
+	first := store.Create()
+	assert.NoError(t, pool.Submit(first))
+
+	// Give the single worker a moment to pick up the first job so the
+	// queue itself is empty, the next one fills it exactly to capacity and
+	// the one after that is rejected.
+	time.Sleep(20 * time.Millisecond)
+
+	second := store.Create()
+	assert.NoError(t, pool.Submit(second))
+
+	third := store.Create()
+	assert.ErrorIs(t, pool.Submit(third), ErrQueueFull)
+
+	close(block)
+}
+
+func TestPool_BatchJob_RunsProblemsConcurrentlyAndRecordsProgress(t *testing.T) {
+	store := NewMemoryStore()
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		if len(req.CostMatrix) > 0 && req.CostMatrix[0][0] == 99 {
+			return nil, errors.New("bad problem")
+		}
+		return &models.AssignmentResponse{Assignments: []int{0}, TotalCost: req.CostMatrix[0][0]}, nil
+	}
+
+	pool := NewPool(solve, 1, 1, 4)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	job.Problems = []models.BatchProblem{
+		{ID: "a", CostMatrix: [][]float64{{1}}},
+		{ID: "b", CostMatrix: [][]float64{{2}}},
+		{ID: "c", CostMatrix: [][]float64{{99}}},
+	}
+	job.SeedBatch(len(job.Problems), nil)
+	assert.NoError(t, pool.Submit(job))
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusDone
+	}, time.Second, 10*time.Millisecond)
+
+	view := job.View()
+	assert.Equal(t, BatchProgress{Done: 3, Total: 3}, view.Progress)
+	assert.Len(t, view.Results, 3)
+
+	var failed int
+	for _, r := range view.Results {
+		if !r.Success {
+			failed++
+			assert.Equal(t, "c", r.ID)
+		}
+	}
+	assert.Equal(t, 1, failed)
+}
+
+func TestPool_BatchJob_PartialResultsVisibleWhileRunning(t *testing.T) {
+	store := NewMemoryStore()
+	release := make(chan struct{})
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		if req.CostMatrix[0][0] == 2 {
+			<-release
+		}
+		return &models.AssignmentResponse{Assignments: []int{0}, TotalCost: req.CostMatrix[0][0]}, nil
+	}
+
+	pool := NewPool(solve, 1, 1, 2)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	job.Problems = []models.BatchProblem{
+		{ID: "fast", CostMatrix: [][]float64{{1}}},
+		{ID: "slow", CostMatrix: [][]float64{{2}}},
+	}
+	job.SeedBatch(len(job.Problems), nil)
+	assert.NoError(t, pool.Submit(job))
+
+	assert.Eventually(t, func() bool {
+		view := job.View()
+		return view.Status == StatusRunning && view.Progress.Done == 1
+	}, time.Second, 5*time.Millisecond)
+
+	mid := job.View()
+	assert.Len(t, mid.Results, 1)
+	assert.Equal(t, "fast", mid.Results[0].ID)
+
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusDone
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, BatchProgress{Done: 2, Total: 2}, job.View().Progress)
+}
+
+func TestPool_BatchJob_SeededResultsCountTowardProgress(t *testing.T) {
+	store := NewMemoryStore()
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		return &models.AssignmentResponse{Assignments: []int{0}}, nil
+	}
+
+	pool := NewPool(solve, 1, 1, 2)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	job.Problems = []models.BatchProblem{{ID: "valid", CostMatrix: [][]float64{{1}}}}
+	job.SeedBatch(2, []models.BatchResult{{ID: "invalid", Success: false, Error: "bad matrix"}})
+	assert.NoError(t, pool.Submit(job))
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusDone
+	}, time.Second, 10*time.Millisecond)
+
+	view := job.View()
+	assert.Equal(t, BatchProgress{Done: 2, Total: 2}, view.Progress)
+}
+
+func TestPool_BatchJob_CancelAbortsInFlightCalls(t *testing.T) {
+	store := NewMemoryStore()
+	started := make(chan struct{}, 1)
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	pool := NewPool(solve, 1, 1, 2)
+	pool.Start(context.Background())
+
+	job := store.Create()
+	job.Problems = []models.BatchProblem{{ID: "slow", CostMatrix: [][]float64{{1}}}}
+	job.SeedBatch(len(job.Problems), nil)
+	assert.NoError(t, pool.Submit(job))
+
+	<-started
+	assert.NoError(t, store.Cancel(job.ID))
+
+	assert.Eventually(t, func() bool {
+		return job.View().Status == StatusFailed
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "canceled", job.View().Error)
+}
+
+func TestPool_CancelWhileQueuedStaysFailedOnceDequeued(t *testing.T) {
+	store := NewMemoryStore()
+	blockFirst := make(chan struct{})
+	solve := func(ctx context.Context, req models.AssignmentRequest) (*models.AssignmentResponse, error) {
+		<-blockFirst
+		return &models.AssignmentResponse{Assignments: []int{0}}, nil
+	}
+
+	pool := NewPool(solve, 1, 2, 1)
+	pool.Start(context.Background())
+
+	first := store.Create()
+	assert.NoError(t, pool.Submit(first))
+
+	// Give the single worker a moment to pick up "first" so the queue is
+	// empty and "second" sits there, untouched, until "first" finishes.
+	time.Sleep(20 * time.Millisecond)
+
+	second := store.Create()
+	assert.NoError(t, pool.Submit(second))
+
+	assert.NoError(t, store.Cancel(second.ID))
+	assert.Equal(t, StatusFailed, second.View().Status)
+
+	close(blockFirst)
+
+	assert.Eventually(t, func() bool {
+		return first.View().Status == StatusDone
+	}, time.Second, 10*time.Millisecond)
+
+	// second has now been dequeued and given to the worker; it must stay
+	// failed rather than being run and overwritten with a result.
+	time.Sleep(20 * time.Millisecond)
+	view := second.View()
+	assert.Equal(t, StatusFailed, view.Status)
+	assert.Equal(t, "canceled", view.Error)
+	assert.Nil(t, view.Result)
+}
+
+func TestMemoryStore_CancelMarksFailed(t *testing.T) {
+	store := NewMemoryStore()
+	job := store.Create()
+
+	assert.NoError(t, store.Cancel(job.ID))
+	assert.Equal(t, StatusFailed, job.View().Status)
+
+	_, err := store.Get("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore_ListPagination(t *testing.T) {
+	store := NewMemoryStore()
+	for i := 0; i < 5; i++ {
+		store.Create()
+	}
+
+	page, total := store.List(0, 2)
+	assert.Equal(t, 5, total)
+	assert.Len(t, page, 2)
+
+	page, total = store.List(4, 2)
+	assert.Equal(t, 5, total)
+	assert.Len(t, page, 1)
+}
+
+func TestRedisStore_SatisfiesStore(t *testing.T) {
+	var store Store = NewRedisStore("redis://localhost:6379")
+
+	job := store.Create()
+	got, err := store.Get(job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+}
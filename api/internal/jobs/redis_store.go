@@ -0,0 +1,17 @@
+package jobs
+
+// RedisStore is a stub Store named for a Redis backend. It embeds
+// MemoryStore, so Create/Get/List/Cancel are MemoryStore's in-process
+// methods: Addr is stored but never used to talk to Redis, job state does
+// not survive a process restart, and it is not shared across API
+// replicas.
+type RedisStore struct {
+	*MemoryStore
+	Addr string
+}
+
+// NewRedisStore returns a RedisStore that records addr; it makes no
+// connection to Redis.
+func NewRedisStore(addr string) *RedisStore {
+	return &RedisStore{MemoryStore: NewMemoryStore(), Addr: addr}
+}
@@ -0,0 +1,102 @@
+package jobs
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned by Store methods when no job exists for the
+// given ID.
+var ErrNotFound = errors.New("job not found")
+
+// Store persists Job state. The in-memory implementation below is the
+// default; a Redis-backed implementation can satisfy the same interface
+// to share job state across multiple API instances.
+type Store interface {
+	// Create allocates a new pending Job and returns it.
+	Create() *Job
+	// Get returns the job with the given ID.
+	Get(id string) (*Job, error)
+	// List returns jobs ordered by creation time (oldest first), applying
+	// offset/limit pagination, along with the total number of jobs.
+	List(offset, limit int) ([]*Job, int)
+	// Cancel cancels the job's context, if it is still running, and marks
+	// it failed.
+	Cancel(id string) error
+}
+
+// MemoryStore is an in-memory Store implementation. It is the default for
+// a single-instance deployment; it does not survive a process restart and
+// is not shared across replicas.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]*Job)}
+}
+
+// Create implements Store.
+func (s *MemoryStore) Create() *Job {
+	job := &Job{
+		ID:        uuid.New().String(),
+		CreatedAt: time.Now(),
+		status:    StatusPending,
+	}
+
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	return job
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(id string) (*Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return job, nil
+}
+
+// List implements Store.
+func (s *MemoryStore) List(offset, limit int) ([]*Job, int) {
+	s.mu.RLock()
+	all := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		all = append(all, job)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+
+	total := len(all)
+	if offset >= total {
+		return []*Job{}, total
+	}
+	end := offset + limit
+	if end > total || limit <= 0 {
+		end = total
+	}
+	return all[offset:end], total
+}
+
+// Cancel implements Store.
+func (s *MemoryStore) Cancel(id string) error {
+	job, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	job.cancelIfRunning()
+	return nil
+}
@@ -0,0 +1,524 @@
+// Package config centralizes environment-variable parsing for the
+// service, instead of scattering os.Getenv calls across main and the
+// handlers.
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"hopfield-assignment-api/pkg/middleware"
+	"hopfield-assignment-api/pkg/tlsconfig"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config holds all environment-derived settings for the service.
+type Config struct {
+	LogLevel  string // e.g. "info", "debug"
+	LogFormat string // "json" or "text"
+	LogOutput string // "stdout", "stderr", or "file:/path/to/file"
+
+	HopfieldServiceURL string
+	HopfieldTimeout    time.Duration
+
+	Port string
+
+	// AuthMode selects which authenticators buildAuthChain wires up, e.g.
+	// "apikey", "basic", "oidc", or a comma-separated combination. "jwt"
+	// is accepted as an alias for "oidc".
+	AuthMode string
+
+	// JobPoolConcurrency is the number of workers processing async jobs
+	// concurrently.
+	JobPoolConcurrency int
+	// JobQueueDepth is the maximum number of jobs that may be queued
+	// awaiting a free worker before Submit returns ErrQueueFull.
+	JobQueueDepth int
+
+	// BatchStreamConcurrency bounds how many problems in a streamed batch
+	// (POST /solve/batch/stream) are solved concurrently.
+	BatchStreamConcurrency int
+	// AsyncBatchConcurrency bounds how many problems within a single
+	// async batch job (POST /solve/batch/async) are dispatched to the
+	// upstream service concurrently.
+	AsyncBatchConcurrency int
+
+	// TLSCertFile and TLSKeyFile, if both set, make the server listen
+	// with TLS via router.RunTLS instead of plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile, if set alongside TLSCertFile/TLSKeyFile, enables
+	// mTLS: client certificates are required and verified against this
+	// CA bundle.
+	TLSClientCAFile string
+
+	// HopfieldCAFile, if set, is a PEM CA bundle used to verify the
+	// upstream Hopfield service's certificate.
+	HopfieldCAFile string
+	// HopfieldClientCertFile and HopfieldClientKeyFile, if both set,
+	// present a client certificate to the Hopfield service for mTLS.
+	HopfieldClientCertFile string
+	HopfieldClientKeyFile  string
+	// HopfieldInsecureSkipVerify disables TLS verification of the
+	// upstream Hopfield service. It exists for local development only
+	// and must never be set in production.
+	HopfieldInsecureSkipVerify bool
+
+	// HopfieldMaxRetries is the number of retries attempted for a failed
+	// Hopfield call after the initial attempt, for network errors, 5xx
+	// responses and 429 responses only.
+	HopfieldMaxRetries int
+	// HopfieldRetryBaseDelay is the base delay used to compute
+	// exponential backoff with full jitter between retries.
+	HopfieldRetryBaseDelay time.Duration
+
+	// HopfieldMaxIdleConnsPerHost and HopfieldIdleConnTimeout tune the
+	// connection pool used for the Hopfield upstream client.
+	HopfieldMaxIdleConnsPerHost int
+	HopfieldIdleConnTimeout     time.Duration
+
+	// HopfieldBreakerFailureRatio is the fraction of failed requests
+	// (0-1] within the observation window that trips the circuit
+	// breaker open, once HopfieldBreakerMinRequests have been observed.
+	HopfieldBreakerFailureRatio float64
+	// HopfieldBreakerMinRequests is the minimum number of requests
+	// observed before ReadyToTrip considers the failure ratio.
+	HopfieldBreakerMinRequests uint32
+	// HopfieldBreakerCooldown is how long the breaker stays open before
+	// allowing a trial request through in the half-open state.
+	HopfieldBreakerCooldown time.Duration
+	// HopfieldBreakerHalfOpenMaxRequests is the number of trial requests
+	// allowed through while the breaker is half-open.
+	HopfieldBreakerHalfOpenMaxRequests uint32
+
+	// RateLimitSolveRPS and RateLimitSolveBurst configure the per-key
+	// token bucket applied to POST /api/v1/solve.
+	RateLimitSolveRPS   float64
+	RateLimitSolveBurst int
+	// RateLimitBatchRPS and RateLimitBatchBurst configure the per-key
+	// token bucket applied to POST /api/v1/solve/batch,
+	// /api/v1/solve/batch/stream and /api/v1/solve/batch/async.
+	RateLimitBatchRPS   float64
+	RateLimitBatchBurst int
+}
+
+// Default values used when the corresponding environment variable is
+// unset or empty.
+const (
+	defaultLogLevel               = "info"
+	defaultLogFormat              = "json"
+	defaultLogOutput              = "stdout"
+	defaultHopfieldServiceURL     = "http://hopfield-service:5000"
+	defaultHopfieldTimeout        = 30 * time.Second
+	defaultPort                   = "8080"
+	defaultAuthMode               = "apikey"
+	defaultJobPoolConcurrency     = 4
+	defaultJobQueueDepth          = 100
+	defaultBatchStreamConcurrency = 4
+	defaultAsyncBatchConcurrency  = 4
+
+	defaultHopfieldMaxRetries          = 2
+	defaultHopfieldRetryBaseDelay      = 100 * time.Millisecond
+	defaultHopfieldMaxIdleConnsPerHost = 10
+	defaultHopfieldIdleConnTimeout     = 90 * time.Second
+
+	defaultHopfieldBreakerFailureRatio        = 0.5
+	defaultHopfieldBreakerMinRequests         = 10
+	defaultHopfieldBreakerCooldown            = 30 * time.Second
+	defaultHopfieldBreakerHalfOpenMaxRequests = 1
+
+	defaultRateLimitSolveRPS   = 5
+	defaultRateLimitSolveBurst = 10
+	defaultRateLimitBatchRPS   = 1
+	defaultRateLimitBatchBurst = 3
+)
+
+// Load reads the service configuration from the environment, applying
+// defaults for anything that is unset, and validates the result.
+func Load() (*Config, error) {
+	cfg := &Config{
+		LogLevel:           getEnvOrDefault("LOG_LEVEL", defaultLogLevel),
+		LogFormat:          getEnvOrDefault("LOG_FORMAT", defaultLogFormat),
+		LogOutput:          getEnvOrDefault("LOG_OUTPUT", defaultLogOutput),
+		HopfieldServiceURL: getEnvOrDefault("HOPFIELD_SERVICE_URL", defaultHopfieldServiceURL),
+		Port:               getEnvOrDefault("PORT", defaultPort),
+		AuthMode:           getEnvOrDefault("AUTH_MODE", defaultAuthMode),
+
+		TLSCertFile:     os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:      os.Getenv("TLS_KEY_FILE"),
+		TLSClientCAFile: os.Getenv("TLS_CLIENT_CA_FILE"),
+
+		HopfieldCAFile:         os.Getenv("HOPFIELD_CA_FILE"),
+		HopfieldClientCertFile: os.Getenv("HOPFIELD_CLIENT_CERT_FILE"),
+		HopfieldClientKeyFile:  os.Getenv("HOPFIELD_CLIENT_KEY_FILE"),
+	}
+
+	if raw := os.Getenv("HOPFIELD_TLS_INSECURE_SKIP_VERIFY"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_TLS_INSECURE_SKIP_VERIFY %q: %w", raw, err)
+		}
+		cfg.HopfieldInsecureSkipVerify = parsed
+	}
+
+	timeout := defaultHopfieldTimeout
+	if raw := os.Getenv("HOPFIELD_TIMEOUT"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_TIMEOUT %q: %w", raw, err)
+		}
+		timeout = parsed
+	}
+	cfg.HopfieldTimeout = timeout
+
+	concurrency := defaultJobPoolConcurrency
+	if raw := os.Getenv("JOB_POOL_CONCURRENCY"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JOB_POOL_CONCURRENCY %q: %w", raw, err)
+		}
+		concurrency = parsed
+	}
+	cfg.JobPoolConcurrency = concurrency
+
+	queueDepth := defaultJobQueueDepth
+	if raw := os.Getenv("JOB_QUEUE_DEPTH"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JOB_QUEUE_DEPTH %q: %w", raw, err)
+		}
+		queueDepth = parsed
+	}
+	cfg.JobQueueDepth = queueDepth
+
+	streamConcurrency := defaultBatchStreamConcurrency
+	if raw := os.Getenv("BATCH_STREAM_CONCURRENCY"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BATCH_STREAM_CONCURRENCY %q: %w", raw, err)
+		}
+		streamConcurrency = parsed
+	}
+	cfg.BatchStreamConcurrency = streamConcurrency
+
+	asyncBatchConcurrency := defaultAsyncBatchConcurrency
+	if raw := os.Getenv("ASYNC_BATCH_CONCURRENCY"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASYNC_BATCH_CONCURRENCY %q: %w", raw, err)
+		}
+		asyncBatchConcurrency = parsed
+	}
+	cfg.AsyncBatchConcurrency = asyncBatchConcurrency
+
+	maxRetries := defaultHopfieldMaxRetries
+	if raw := os.Getenv("HOPFIELD_MAX_RETRIES"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_MAX_RETRIES %q: %w", raw, err)
+		}
+		maxRetries = parsed
+	}
+	cfg.HopfieldMaxRetries = maxRetries
+
+	retryBaseDelay := defaultHopfieldRetryBaseDelay
+	if raw := os.Getenv("HOPFIELD_RETRY_BASE_DELAY"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_RETRY_BASE_DELAY %q: %w", raw, err)
+		}
+		retryBaseDelay = parsed
+	}
+	cfg.HopfieldRetryBaseDelay = retryBaseDelay
+
+	maxIdleConnsPerHost := defaultHopfieldMaxIdleConnsPerHost
+	if raw := os.Getenv("HOPFIELD_MAX_IDLE_CONNS_PER_HOST"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_MAX_IDLE_CONNS_PER_HOST %q: %w", raw, err)
+		}
+		maxIdleConnsPerHost = parsed
+	}
+	cfg.HopfieldMaxIdleConnsPerHost = maxIdleConnsPerHost
+
+	idleConnTimeout := defaultHopfieldIdleConnTimeout
+	if raw := os.Getenv("HOPFIELD_IDLE_CONN_TIMEOUT"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_IDLE_CONN_TIMEOUT %q: %w", raw, err)
+		}
+		idleConnTimeout = parsed
+	}
+	cfg.HopfieldIdleConnTimeout = idleConnTimeout
+
+	breakerFailureRatio := defaultHopfieldBreakerFailureRatio
+	if raw := os.Getenv("HOPFIELD_BREAKER_FAILURE_RATIO"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_BREAKER_FAILURE_RATIO %q: %w", raw, err)
+		}
+		breakerFailureRatio = parsed
+	}
+	cfg.HopfieldBreakerFailureRatio = breakerFailureRatio
+
+	breakerMinRequests := uint32(defaultHopfieldBreakerMinRequests)
+	if raw := os.Getenv("HOPFIELD_BREAKER_MIN_REQUESTS"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_BREAKER_MIN_REQUESTS %q: %w", raw, err)
+		}
+		breakerMinRequests = uint32(parsed)
+	}
+	cfg.HopfieldBreakerMinRequests = breakerMinRequests
+
+	breakerCooldown := defaultHopfieldBreakerCooldown
+	if raw := os.Getenv("HOPFIELD_BREAKER_COOLDOWN"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_BREAKER_COOLDOWN %q: %w", raw, err)
+		}
+		breakerCooldown = parsed
+	}
+	cfg.HopfieldBreakerCooldown = breakerCooldown
+
+	breakerHalfOpenMaxRequests := uint32(defaultHopfieldBreakerHalfOpenMaxRequests)
+	if raw := os.Getenv("HOPFIELD_BREAKER_HALF_OPEN_MAX_REQUESTS"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HOPFIELD_BREAKER_HALF_OPEN_MAX_REQUESTS %q: %w", raw, err)
+		}
+		breakerHalfOpenMaxRequests = uint32(parsed)
+	}
+	cfg.HopfieldBreakerHalfOpenMaxRequests = breakerHalfOpenMaxRequests
+
+	solveRPS := float64(defaultRateLimitSolveRPS)
+	if raw := os.Getenv("RATE_LIMIT_SOLVE"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_SOLVE %q: %w", raw, err)
+		}
+		solveRPS = parsed
+	}
+	cfg.RateLimitSolveRPS = solveRPS
+
+	solveBurst := defaultRateLimitSolveBurst
+	if raw := os.Getenv("RATE_LIMIT_SOLVE_BURST"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_SOLVE_BURST %q: %w", raw, err)
+		}
+		solveBurst = parsed
+	}
+	cfg.RateLimitSolveBurst = solveBurst
+
+	batchRPS := float64(defaultRateLimitBatchRPS)
+	if raw := os.Getenv("RATE_LIMIT_BATCH"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_BATCH %q: %w", raw, err)
+		}
+		batchRPS = parsed
+	}
+	cfg.RateLimitBatchRPS = batchRPS
+
+	batchBurst := defaultRateLimitBatchBurst
+	if raw := os.Getenv("RATE_LIMIT_BATCH_BURST"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_BATCH_BURST %q: %w", raw, err)
+		}
+		batchBurst = parsed
+	}
+	cfg.RateLimitBatchBurst = batchBurst
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		return fmt.Errorf("invalid LOG_FORMAT %q: must be %q or %q", c.LogFormat, "json", "text")
+	}
+	if c.HopfieldTimeout <= 0 {
+		return fmt.Errorf("invalid HOPFIELD_TIMEOUT %q: must be positive", c.HopfieldTimeout)
+	}
+	if _, err := strconv.Atoi(c.Port); err != nil {
+		return fmt.Errorf("invalid PORT %q: %w", c.Port, err)
+	}
+	if c.JobPoolConcurrency <= 0 {
+		return fmt.Errorf("invalid JOB_POOL_CONCURRENCY %d: must be positive", c.JobPoolConcurrency)
+	}
+	if c.JobQueueDepth <= 0 {
+		return fmt.Errorf("invalid JOB_QUEUE_DEPTH %d: must be positive", c.JobQueueDepth)
+	}
+	if c.BatchStreamConcurrency <= 0 {
+		return fmt.Errorf("invalid BATCH_STREAM_CONCURRENCY %d: must be positive", c.BatchStreamConcurrency)
+	}
+	if c.AsyncBatchConcurrency <= 0 {
+		return fmt.Errorf("invalid ASYNC_BATCH_CONCURRENCY %d: must be positive", c.AsyncBatchConcurrency)
+	}
+	for mode := range c.AuthModes() {
+		if mode != "apikey" && mode != "basic" && mode != "oidc" {
+			return fmt.Errorf("invalid AUTH_MODE %q: unknown authenticator %q", c.AuthMode, mode)
+		}
+	}
+	if c.TLSClientCAFile != "" && (c.TLSCertFile == "" || c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE to also be set")
+	}
+	if c.HopfieldMaxRetries < 0 {
+		return fmt.Errorf("invalid HOPFIELD_MAX_RETRIES %d: must not be negative", c.HopfieldMaxRetries)
+	}
+	if c.HopfieldRetryBaseDelay <= 0 {
+		return fmt.Errorf("invalid HOPFIELD_RETRY_BASE_DELAY %q: must be positive", c.HopfieldRetryBaseDelay)
+	}
+	if c.HopfieldMaxIdleConnsPerHost <= 0 {
+		return fmt.Errorf("invalid HOPFIELD_MAX_IDLE_CONNS_PER_HOST %d: must be positive", c.HopfieldMaxIdleConnsPerHost)
+	}
+	if c.HopfieldIdleConnTimeout <= 0 {
+		return fmt.Errorf("invalid HOPFIELD_IDLE_CONN_TIMEOUT %q: must be positive", c.HopfieldIdleConnTimeout)
+	}
+	if c.HopfieldBreakerFailureRatio <= 0 || c.HopfieldBreakerFailureRatio > 1 {
+		return fmt.Errorf("invalid HOPFIELD_BREAKER_FAILURE_RATIO %v: must be in (0, 1]", c.HopfieldBreakerFailureRatio)
+	}
+	if c.HopfieldBreakerMinRequests == 0 {
+		return fmt.Errorf("invalid HOPFIELD_BREAKER_MIN_REQUESTS %d: must be positive", c.HopfieldBreakerMinRequests)
+	}
+	if c.HopfieldBreakerCooldown <= 0 {
+		return fmt.Errorf("invalid HOPFIELD_BREAKER_COOLDOWN %q: must be positive", c.HopfieldBreakerCooldown)
+	}
+	if c.HopfieldBreakerHalfOpenMaxRequests == 0 {
+		return fmt.Errorf("invalid HOPFIELD_BREAKER_HALF_OPEN_MAX_REQUESTS %d: must be positive", c.HopfieldBreakerHalfOpenMaxRequests)
+	}
+	if c.RateLimitSolveRPS <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_SOLVE %v: must be positive", c.RateLimitSolveRPS)
+	}
+	if c.RateLimitSolveBurst <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_SOLVE_BURST %d: must be positive", c.RateLimitSolveBurst)
+	}
+	if c.RateLimitBatchRPS <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_BATCH %v: must be positive", c.RateLimitBatchRPS)
+	}
+	if c.RateLimitBatchBurst <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_BATCH_BURST %d: must be positive", c.RateLimitBatchBurst)
+	}
+	return nil
+}
+
+// ServerTLSConfig builds the server-side TLS configuration from
+// TLS_CERT_FILE, TLS_KEY_FILE and TLS_CLIENT_CA_FILE.
+func (c *Config) ServerTLSConfig() tlsconfig.ServerConfig {
+	return tlsconfig.ServerConfig{
+		CertFile:     c.TLSCertFile,
+		KeyFile:      c.TLSKeyFile,
+		ClientCAFile: c.TLSClientCAFile,
+	}
+}
+
+// TLSEnabled reports whether the server should listen with TLS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// HopfieldTLSConfig builds the TLS configuration for the outbound
+// Hopfield service client from HOPFIELD_CA_FILE,
+// HOPFIELD_CLIENT_CERT_FILE, HOPFIELD_CLIENT_KEY_FILE and
+// HOPFIELD_TLS_INSECURE_SKIP_VERIFY.
+func (c *Config) HopfieldTLSConfig() tlsconfig.ClientConfig {
+	return tlsconfig.ClientConfig{
+		CAFile:             c.HopfieldCAFile,
+		CertFile:           c.HopfieldClientCertFile,
+		KeyFile:            c.HopfieldClientKeyFile,
+		InsecureSkipVerify: c.HopfieldInsecureSkipVerify,
+	}
+}
+
+// SolveRateLimit builds the rate limit configuration for POST
+// /api/v1/solve from RATE_LIMIT_SOLVE and RATE_LIMIT_SOLVE_BURST.
+func (c *Config) SolveRateLimit() middleware.RateLimitConfig {
+	return middleware.RateLimitConfig{RPS: c.RateLimitSolveRPS, Burst: c.RateLimitSolveBurst}
+}
+
+// BatchRateLimit builds the rate limit configuration for POST
+// /api/v1/solve/batch, /api/v1/solve/batch/stream and
+// /api/v1/solve/batch/async from RATE_LIMIT_BATCH and
+// RATE_LIMIT_BATCH_BURST.
+func (c *Config) BatchRateLimit() middleware.RateLimitConfig {
+	return middleware.RateLimitConfig{RPS: c.RateLimitBatchRPS, Burst: c.RateLimitBatchBurst}
+}
+
+// AuthModes splits AuthMode into the set of authenticator kinds it names.
+// "jwt" is accepted as an alias for "oidc" since that is the more
+// familiar name for bearer-token auth.
+func (c *Config) AuthModes() map[string]bool {
+	modes := make(map[string]bool)
+	for _, mode := range strings.Split(c.AuthMode, ",") {
+		mode = strings.TrimSpace(mode)
+		if mode == "jwt" {
+			mode = "oidc"
+		}
+		if mode != "" {
+			modes[mode] = true
+		}
+	}
+	return modes
+}
+
+// Logger builds a *logrus.Logger from the configured level, format and
+// output. An invalid LOG_LEVEL falls back to logrus.InfoLevel with a
+// warning logged on the returned logger, rather than failing startup.
+func (c *Config) Logger() *logrus.Logger {
+	logger := logrus.New()
+
+	if c.LogFormat == "text" {
+		logger.SetFormatter(&logrus.TextFormatter{})
+	} else {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	logger.SetOutput(c.logOutputWriter())
+
+	level, err := logrus.ParseLevel(c.LogLevel)
+	if err != nil {
+		logger.SetLevel(logrus.InfoLevel)
+		logger.WithField("log_level", c.LogLevel).Warn("Invalid LOG_LEVEL, falling back to info")
+		return logger
+	}
+	logger.SetLevel(level)
+
+	return logger
+}
+
+func (c *Config) logOutputWriter() io.Writer {
+	switch {
+	case c.LogOutput == "stderr":
+		return os.Stderr
+	case c.LogOutput == "stdout", c.LogOutput == "":
+		return os.Stdout
+	case strings.HasPrefix(c.LogOutput, "file:"):
+		path := strings.TrimPrefix(c.LogOutput, "file:")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			logrus.WithError(err).WithField("path", path).Warn("Could not open LOG_OUTPUT file, falling back to stdout")
+			return os.Stdout
+		}
+		return f
+	default:
+		logrus.WithField("log_output", c.LogOutput).Warn("Unrecognized LOG_OUTPUT, falling back to stdout")
+		return os.Stdout
+	}
+}
+
+func getEnvOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
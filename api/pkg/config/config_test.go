@@ -0,0 +1,220 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"hopfield-assignment-api/pkg/middleware"
+)
+
+func TestConfig_Logger_LevelParsing(t *testing.T) {
+	tests := []struct {
+		name          string
+		level         string
+		expectedLevel logrus.Level
+	}{
+		{name: "debug", level: "debug", expectedLevel: logrus.DebugLevel},
+		{name: "warn", level: "warn", expectedLevel: logrus.WarnLevel},
+		{name: "empty falls back to info", level: "", expectedLevel: logrus.InfoLevel},
+		{name: "invalid falls back to info", level: "not-a-level", expectedLevel: logrus.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{LogLevel: tt.level, LogFormat: "json", LogOutput: "stdout"}
+			logger := cfg.Logger()
+			assert.Equal(t, tt.expectedLevel, logger.GetLevel())
+		})
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	for _, key := range []string{
+		"LOG_LEVEL", "LOG_FORMAT", "LOG_OUTPUT",
+		"HOPFIELD_SERVICE_URL", "HOPFIELD_TIMEOUT", "PORT", "AUTH_MODE",
+		"JOB_POOL_CONCURRENCY", "JOB_QUEUE_DEPTH", "BATCH_STREAM_CONCURRENCY", "ASYNC_BATCH_CONCURRENCY",
+		"TLS_CERT_FILE", "TLS_KEY_FILE", "TLS_CLIENT_CA_FILE",
+		"HOPFIELD_CA_FILE", "HOPFIELD_CLIENT_CERT_FILE", "HOPFIELD_CLIENT_KEY_FILE",
+		"HOPFIELD_TLS_INSECURE_SKIP_VERIFY",
+		"HOPFIELD_MAX_RETRIES", "HOPFIELD_RETRY_BASE_DELAY",
+		"HOPFIELD_MAX_IDLE_CONNS_PER_HOST", "HOPFIELD_IDLE_CONN_TIMEOUT",
+		"HOPFIELD_BREAKER_FAILURE_RATIO", "HOPFIELD_BREAKER_MIN_REQUESTS",
+		"HOPFIELD_BREAKER_COOLDOWN", "HOPFIELD_BREAKER_HALF_OPEN_MAX_REQUESTS",
+		"RATE_LIMIT_SOLVE", "RATE_LIMIT_SOLVE_BURST",
+		"RATE_LIMIT_BATCH", "RATE_LIMIT_BATCH_BURST",
+	} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, "json", cfg.LogFormat)
+	assert.Equal(t, "stdout", cfg.LogOutput)
+	assert.Equal(t, "http://hopfield-service:5000", cfg.HopfieldServiceURL)
+	assert.Equal(t, 30*time.Second, cfg.HopfieldTimeout)
+	assert.Equal(t, "8080", cfg.Port)
+	assert.Equal(t, "apikey", cfg.AuthMode)
+	assert.Equal(t, 4, cfg.JobPoolConcurrency)
+	assert.Equal(t, 100, cfg.JobQueueDepth)
+	assert.Equal(t, 4, cfg.BatchStreamConcurrency)
+	assert.Equal(t, 4, cfg.AsyncBatchConcurrency)
+	assert.False(t, cfg.TLSEnabled())
+	assert.True(t, cfg.HopfieldTLSConfig().IsZero())
+	assert.Equal(t, 2, cfg.HopfieldMaxRetries)
+	assert.Equal(t, 100*time.Millisecond, cfg.HopfieldRetryBaseDelay)
+	assert.Equal(t, 10, cfg.HopfieldMaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, cfg.HopfieldIdleConnTimeout)
+	assert.Equal(t, 0.5, cfg.HopfieldBreakerFailureRatio)
+	assert.Equal(t, uint32(10), cfg.HopfieldBreakerMinRequests)
+	assert.Equal(t, 30*time.Second, cfg.HopfieldBreakerCooldown)
+	assert.Equal(t, uint32(1), cfg.HopfieldBreakerHalfOpenMaxRequests)
+	assert.Equal(t, 5.0, cfg.RateLimitSolveRPS)
+	assert.Equal(t, 10, cfg.RateLimitSolveBurst)
+	assert.Equal(t, 1.0, cfg.RateLimitBatchRPS)
+	assert.Equal(t, 3, cfg.RateLimitBatchBurst)
+}
+
+func TestLoad_ResilienceValidation(t *testing.T) {
+	t.Run("negative max retries", func(t *testing.T) {
+		t.Setenv("HOPFIELD_MAX_RETRIES", "-1")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid breaker failure ratio", func(t *testing.T) {
+		t.Setenv("HOPFIELD_BREAKER_FAILURE_RATIO", "1.5")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("zero breaker min requests", func(t *testing.T) {
+		t.Setenv("HOPFIELD_BREAKER_MIN_REQUESTS", "0")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid retry base delay", func(t *testing.T) {
+		t.Setenv("HOPFIELD_RETRY_BASE_DELAY", "not-a-duration")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad_RateLimits(t *testing.T) {
+	t.Run("custom values populate the builders", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_SOLVE", "20")
+		t.Setenv("RATE_LIMIT_SOLVE_BURST", "40")
+		t.Setenv("RATE_LIMIT_BATCH", "2")
+		t.Setenv("RATE_LIMIT_BATCH_BURST", "5")
+
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, middleware.RateLimitConfig{RPS: 20, Burst: 40}, cfg.SolveRateLimit())
+		assert.Equal(t, middleware.RateLimitConfig{RPS: 2, Burst: 5}, cfg.BatchRateLimit())
+	})
+
+	t.Run("non-positive solve rate is rejected", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_SOLVE", "0")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("non-positive batch burst is rejected", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_BATCH_BURST", "0")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad_AuthMode(t *testing.T) {
+	t.Run("default is apikey only", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", "")
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"apikey": true}, cfg.AuthModes())
+	})
+
+	t.Run("jwt is an alias for oidc", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", "jwt")
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"oidc": true}, cfg.AuthModes())
+	})
+
+	t.Run("comma-separated combination enables multiple authenticators", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", "apikey, oidc")
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"apikey": true, "oidc": true}, cfg.AuthModes())
+	})
+
+	t.Run("unknown mode is rejected", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", "saml")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad_TLS(t *testing.T) {
+	t.Run("client CA without cert and key is rejected", func(t *testing.T) {
+		t.Setenv("TLS_CLIENT_CA_FILE", "/tmp/ca.pem")
+		t.Setenv("TLS_CERT_FILE", "")
+		t.Setenv("TLS_KEY_FILE", "")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid insecure skip verify", func(t *testing.T) {
+		t.Setenv("HOPFIELD_TLS_INSECURE_SKIP_VERIFY", "not-a-bool")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("hopfield TLS config populated from env", func(t *testing.T) {
+		t.Setenv("HOPFIELD_CA_FILE", "/tmp/ca.pem")
+		t.Setenv("HOPFIELD_CLIENT_CERT_FILE", "/tmp/client.pem")
+		t.Setenv("HOPFIELD_CLIENT_KEY_FILE", "/tmp/client.key")
+		cfg, err := Load()
+		assert.NoError(t, err)
+		tlsCfg := cfg.HopfieldTLSConfig()
+		assert.False(t, tlsCfg.IsZero())
+		assert.Equal(t, "/tmp/ca.pem", tlsCfg.CAFile)
+	})
+}
+
+func TestLoad_HopfieldTimeout(t *testing.T) {
+	t.Run("valid duration", func(t *testing.T) {
+		t.Setenv("HOPFIELD_TIMEOUT", "5s")
+		cfg, err := Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Second, cfg.HopfieldTimeout)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		t.Setenv("HOPFIELD_TIMEOUT", "not-a-duration")
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad_InvalidLogFormat(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "xml")
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_InvalidPort(t *testing.T) {
+	t.Setenv("PORT", "not-a-port")
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_HopfieldServiceURL(t *testing.T) {
+	t.Setenv("HOPFIELD_SERVICE_URL", "https://hopfield.internal:9000")
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://hopfield.internal:9000", cfg.HopfieldServiceURL)
+}
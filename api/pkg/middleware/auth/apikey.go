@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyAuthenticator authenticates requests that carry a static API key,
+// either via the X-API-Key header or an "Authorization: Bearer <key>"
+// header. Keys map to the identity (and scopes) they are allowed to act
+// as.
+type APIKeyAuthenticator struct {
+	// keys maps an API key to the subject/scopes it authenticates as.
+	keys map[string]Identity
+}
+
+// NewAPIKeyAuthenticator builds an APIKeyAuthenticator from a map of API
+// key to identity.
+func NewAPIKeyAuthenticator(keys map[string]Identity) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: keys}
+}
+
+// NewAPIKeyAuthenticatorFromEnv loads API keys from the API_KEYS
+// environment variable, a comma-separated list of "key:subject:scope1|scope2"
+// entries (scopes are optional and pipe-separated). It falls back to the
+// legacy single-key API_KEY variable, granting it the solve:write scope,
+// for backwards compatibility with existing deployments.
+func NewAPIKeyAuthenticatorFromEnv() *APIKeyAuthenticator {
+	keys := make(map[string]Identity)
+
+	if raw := os.Getenv("API_KEYS"); raw != "" {
+		for _, entry := range strings.Split(raw, ",") {
+			parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+			if len(parts) == 0 || parts[0] == "" {
+				continue
+			}
+			identity := Identity{Subject: parts[0]}
+			if len(parts) > 1 {
+				identity.Subject = parts[1]
+			}
+			if len(parts) > 2 {
+				identity.Scopes = strings.Split(parts[2], "|")
+			}
+			keys[parts[0]] = identity
+		}
+	}
+
+	if legacyKey := os.Getenv("API_KEY"); legacyKey != "" {
+		keys[legacyKey] = Identity{Subject: "default", Scopes: []string{"solve:write"}}
+	}
+
+	return NewAPIKeyAuthenticator(keys)
+}
+
+// Authenticate implements Authenticator.
+func (a *APIKeyAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	apiKey := c.GetHeader("X-API-Key")
+	if apiKey == "" {
+		if authHeader := c.GetHeader("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+			apiKey = strings.TrimPrefix(authHeader, "Bearer ")
+		}
+	}
+	if apiKey == "" {
+		return nil, nil
+	}
+
+	for key, identity := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			id := identity
+			return &id, nil
+		}
+	}
+
+	return nil, ErrCredentialsRejected
+}
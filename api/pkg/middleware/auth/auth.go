@@ -0,0 +1,151 @@
+// Package auth provides a pluggable, filter-chain style authentication
+// middleware for the Gin router. Each Authenticator inspects the incoming
+// request and either produces an Identity, declines to handle the request
+// (so the next authenticator in the chain gets a chance), or rejects it
+// outright with an error.
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// Identity represents the caller that was resolved by an Authenticator.
+type Identity struct {
+	Subject string
+	Scopes  []string
+}
+
+// HasScope reports whether the identity was granted the given scope.
+func (i *Identity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrCredentialsRejected signals that an Authenticator recognized the
+// credentials presented (e.g. an X-API-Key header was set) but rejected
+// them, as opposed to the request simply not carrying that credential
+// type at all.
+var ErrCredentialsRejected = errors.New("credentials rejected")
+
+// Authenticator attempts to resolve an Identity from the request. It
+// returns (nil, nil) when the request does not carry the kind of
+// credential this authenticator understands, so the chain can fall
+// through to the next one. It returns (nil, ErrCredentialsRejected) (or a
+// wrapped error) when the credential was present but invalid.
+type Authenticator interface {
+	Authenticate(c *gin.Context) (*Identity, error)
+}
+
+// Chain composes a list of Authenticators into a single Gin middleware.
+// Authenticators are tried in order; the first one to return a non-nil
+// Identity wins. ErrCredentialsRejected from one authenticator does not
+// stop the chain: a request can carry a credential one authenticator
+// doesn't recognize (e.g. an OIDC bearer token presented to a chain that
+// also has an APIKeyAuthenticator ahead of it), so rejection only falls
+// through to the next authenticator rather than aborting outright. If
+// every authenticator declines or rejects, the request is refused: 403 if
+// any authenticator actively rejected a credential, 401 if none of them
+// saw one at all. Any other error (e.g. a JWKS fetch failure) stops the
+// chain and rejects the request immediately.
+type Chain struct {
+	logger         *logrus.Logger
+	authenticators []Authenticator
+}
+
+// NewChain builds a Chain from the given authenticators, tried in order.
+func NewChain(logger *logrus.Logger, authenticators ...Authenticator) *Chain {
+	return &Chain{logger: logger, authenticators: authenticators}
+}
+
+// Middleware returns the Gin middleware that runs the chain.
+func (c *Chain) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rejected := false
+
+		for _, a := range c.authenticators {
+			identity, err := a.Authenticate(ctx)
+			if err != nil {
+				if errors.Is(err, ErrCredentialsRejected) {
+					c.logger.WithFields(logrus.Fields{
+						"ip":   ctx.ClientIP(),
+						"path": ctx.Request.URL.Path,
+					}).WithError(err).Warn("Authenticator rejected credentials, trying next")
+					rejected = true
+					continue
+				}
+
+				c.logger.WithFields(logrus.Fields{
+					"ip":   ctx.ClientIP(),
+					"path": ctx.Request.URL.Path,
+				}).WithError(err).Warn("Authentication rejected")
+				ctx.JSON(http.StatusForbidden, gin.H{
+					"success": false,
+					"error":   "Invalid credentials",
+				})
+				ctx.Abort()
+				return
+			}
+			if identity != nil {
+				ctx.Set("authenticated", true)
+				ctx.Set("subject", identity.Subject)
+				ctx.Set("scopes", identity.Scopes)
+				ctx.Next()
+				return
+			}
+		}
+
+		if rejected {
+			c.logger.WithFields(logrus.Fields{
+				"ip":   ctx.ClientIP(),
+				"path": ctx.Request.URL.Path,
+			}).Warn("Request rejected by every authenticator that recognized its credentials")
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error":   "Invalid credentials",
+			})
+			ctx.Abort()
+			return
+		}
+
+		c.logger.WithFields(logrus.Fields{
+			"ip":   ctx.ClientIP(),
+			"path": ctx.Request.URL.Path,
+		}).Warn("Request without valid credentials")
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Authentication required",
+		})
+		ctx.Abort()
+	}
+}
+
+// RequireScope returns middleware that enforces the authenticated identity
+// was granted the given scope. It must run after Chain.Middleware() so
+// that "scopes" has already been populated in the context.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, _ := c.Get("scopes")
+		granted, _ := scopes.([]string)
+
+		for _, s := range granted {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "Missing required scope: " + scope,
+		})
+		c.Abort()
+	}
+}
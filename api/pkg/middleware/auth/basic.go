@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BasicAuthenticator authenticates requests using HTTP Basic auth against
+// a set of bcrypt-hashed passwords.
+type BasicAuthenticator struct {
+	// users maps username to bcrypt password hash.
+	users map[string]string
+}
+
+// NewBasicAuthenticator builds a BasicAuthenticator from a username ->
+// bcrypt-hash map.
+func NewBasicAuthenticator(users map[string]string) *BasicAuthenticator {
+	return &BasicAuthenticator{users: users}
+}
+
+// LoadUserFile reads a "htpasswd"-style file of "username:bcrypt-hash"
+// lines (blank lines and lines starting with "#" are ignored) and returns
+// a BasicAuthenticator backed by it.
+func LoadUserFile(path string) (*BasicAuthenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening basic auth user file: %w", err)
+	}
+	defer f.Close()
+
+	users := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed basic auth user file entry: %q", line)
+		}
+		users[parts[0]] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading basic auth user file: %w", err)
+	}
+
+	return NewBasicAuthenticator(users), nil
+}
+
+// Authenticate implements Authenticator.
+func (a *BasicAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	hash, known := a.users[username]
+	if !known {
+		return nil, ErrCredentialsRejected
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return nil, ErrCredentialsRejected
+	}
+
+	// The user file format has no room for per-user scopes, so every
+	// authenticated Basic user is granted solve:write, matching the legacy
+	// single-key API_KEY fallback: anyone who can authenticate at all is
+	// allowed to call the solve routes.
+	return &Identity{Subject: username, Scopes: []string{"solve:write"}}, nil
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hashing password: %v", err)
+	}
+	return string(hash)
+}
+
+func TestBasicAuthenticator_ValidPasswordGrantsSolveWrite(t *testing.T) {
+	auth := NewBasicAuthenticator(map[string]string{
+		"alice": hashPassword(t, "correct-horse"),
+	})
+
+	ctx, _ := ginTestContext("")
+	ctx.Request.SetBasicAuth("alice", "correct-horse")
+
+	identity, err := auth.Authenticate(ctx)
+	assert.NoError(t, err)
+	if assert.NotNil(t, identity) {
+		assert.Equal(t, "alice", identity.Subject)
+		assert.Equal(t, []string{"solve:write"}, identity.Scopes)
+	}
+}
+
+func TestBasicAuthenticator_InvalidPasswordRejected(t *testing.T) {
+	auth := NewBasicAuthenticator(map[string]string{
+		"alice": hashPassword(t, "correct-horse"),
+	})
+
+	ctx, _ := ginTestContext("")
+	ctx.Request.SetBasicAuth("alice", "wrong-password")
+
+	identity, err := auth.Authenticate(ctx)
+	assert.ErrorIs(t, err, ErrCredentialsRejected)
+	assert.Nil(t, identity)
+}
+
+func TestBasicAuthenticator_UnknownUserRejected(t *testing.T) {
+	auth := NewBasicAuthenticator(map[string]string{
+		"alice": hashPassword(t, "correct-horse"),
+	})
+
+	ctx, _ := ginTestContext("")
+	ctx.Request.SetBasicAuth("mallory", "whatever")
+
+	identity, err := auth.Authenticate(ctx)
+	assert.ErrorIs(t, err, ErrCredentialsRejected)
+	assert.Nil(t, identity)
+}
+
+func TestBasicAuthenticator_NoCredentialsDeclines(t *testing.T) {
+	auth := NewBasicAuthenticator(map[string]string{
+		"alice": hashPassword(t, "correct-horse"),
+	})
+
+	ctx, _ := ginTestContext("")
+
+	identity, err := auth.Authenticate(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+}
+
+func TestLoadUserFile_ValidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.htpasswd")
+	contents := "# comment line\n\nalice:" + hashPassword(t, "correct-horse") + "\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing user file: %v", err)
+	}
+
+	auth, err := LoadUserFile(path)
+	assert.NoError(t, err)
+
+	ctx, _ := ginTestContext("")
+	ctx.Request.SetBasicAuth("alice", "correct-horse")
+	identity, err := auth.Authenticate(ctx)
+	assert.NoError(t, err)
+	if assert.NotNil(t, identity) {
+		assert.Equal(t, "alice", identity.Subject)
+	}
+}
+
+func TestLoadUserFile_MalformedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.htpasswd")
+	if err := os.WriteFile(path, []byte("alice-no-colon\n"), 0o600); err != nil {
+		t.Fatalf("writing user file: %v", err)
+	}
+
+	_, err := LoadUserFile(path)
+	assert.Error(t, err)
+}
+
+func TestLoadUserFile_MissingFile(t *testing.T) {
+	_, err := LoadUserFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.Error(t, err)
+}
+
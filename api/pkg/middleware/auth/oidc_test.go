@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// ginTestContext builds a gin.Context for a GET request carrying the given
+// Authorization header, without needing to spin up a full router.
+func ginTestContext(authorizationHeader string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if authorizationHeader != "" {
+		c.Request.Header.Set("Authorization", authorizationHeader)
+	}
+	return c, w
+}
+
+// testIssuer serves a JWKS containing a single RSA key and signs tokens
+// with its private half, so tests can exercise OIDCAuthenticator against
+// a real (if minimal) JWKS endpoint rather than mocking key lookup.
+type testIssuer struct {
+	key    *rsa.PrivateKey
+	kid    string
+	server *httptest.Server
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+
+	iss := &testIssuer{key: key, kid: "test-key-1"}
+	iss.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]interface{}{
+				{
+					"kty": "RSA",
+					"kid": iss.kid,
+					"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+				},
+			},
+		})
+	}))
+	t.Cleanup(iss.server.Close)
+	return iss
+}
+
+func (iss *testIssuer) sign(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = iss.kid
+	signed, err := token.SignedString(iss.key)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return signed
+}
+
+func (iss *testIssuer) authenticator() *OIDCAuthenticator {
+	return NewOIDCAuthenticator("https://issuer.example.com", "hopfield-api", iss.server.URL)
+}
+
+func validClaims() claims {
+	now := time.Now()
+	return claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-123",
+			Issuer:    "https://issuer.example.com",
+			Audience:  jwt.ClaimStrings{"hopfield-api"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Scope: "solve:write",
+	}
+}
+
+func TestOIDCAuthenticator_ValidToken(t *testing.T) {
+	iss := newTestIssuer(t)
+	token := iss.sign(t, validClaims())
+
+	c, _ := ginTestContext("Bearer " + token)
+	identity, err := iss.authenticator().Authenticate(c)
+	assert.NoError(t, err)
+	if assert.NotNil(t, identity) {
+		assert.Equal(t, "user-123", identity.Subject)
+		assert.Equal(t, []string{"solve:write"}, identity.Scopes)
+	}
+}
+
+func TestOIDCAuthenticator_ExpiredToken(t *testing.T) {
+	iss := newTestIssuer(t)
+	c := validClaims()
+	c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	token := iss.sign(t, c)
+
+	ctx, _ := ginTestContext("Bearer " + token)
+	_, err := iss.authenticator().Authenticate(ctx)
+	assert.ErrorIs(t, err, ErrCredentialsRejected)
+}
+
+func TestOIDCAuthenticator_WrongAudience(t *testing.T) {
+	iss := newTestIssuer(t)
+	c := validClaims()
+	c.Audience = jwt.ClaimStrings{"some-other-service"}
+	token := iss.sign(t, c)
+
+	ctx, _ := ginTestContext("Bearer " + token)
+	_, err := iss.authenticator().Authenticate(ctx)
+	assert.ErrorIs(t, err, ErrCredentialsRejected)
+}
+
+func TestOIDCAuthenticator_UnsignedAlgRejected(t *testing.T) {
+	iss := newTestIssuer(t)
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing unsigned token: %v", err)
+	}
+
+	ctx, _ := ginTestContext("Bearer " + signed)
+	_, err = iss.authenticator().Authenticate(ctx)
+	assert.ErrorIs(t, err, ErrCredentialsRejected)
+}
+
+func TestOIDCAuthenticator_NoBearerHeaderDeclines(t *testing.T) {
+	iss := newTestIssuer(t)
+	ctx, _ := ginTestContext("")
+	identity, err := iss.authenticator().Authenticate(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// ClientCertSubject records the verified client certificate's subject in
+// the request context under "client_cert_subject", so StructuredLogging
+// can include it for mTLS connections. It is a no-op when the request
+// was not made over mTLS.
+func ClientCertSubject() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
+			c.Set("client_cert_subject", c.Request.TLS.PeerCertificates[0].Subject.String())
+		}
+		c.Next()
+	}
+}
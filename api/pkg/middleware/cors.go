@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// corsAllowedOrigins returns the origins configured via the
+// comma-separated CORS_ALLOWED_ORIGINS environment variable. An unset or
+// empty variable allows no origins, so cross-origin access is opt-in. A
+// single "*" entry allows any origin.
+func corsAllowedOrigins() []string {
+	raw := os.Getenv("CORS_ALLOWED_ORIGINS")
+	if raw == "" {
+		return nil
+	}
+
+	var origins []string
+	for _, o := range strings.Split(raw, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// CORS returns a gin.HandlerFunc that sets Access-Control-* headers for
+// requests from an origin allowed by CORS_ALLOWED_ORIGINS, and
+// short-circuits preflight (OPTIONS) requests with a 204. Credentialed
+// cross-origin requests are not supported: Access-Control-Allow-Credentials
+// is never set, and a wildcard configuration echoes "*" rather than the
+// caller's Origin.
+func CORS() gin.HandlerFunc {
+	allowed := corsAllowedOrigins()
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || !corsOriginAllowed(allowed, origin) {
+			c.Next()
+			return
+		}
+
+		if corsWildcard(allowed) {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
+		c.Header("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Authorization, X-API-Key, Content-Type")
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+func corsWildcard(allowed []string) bool {
+	for _, o := range allowed {
+		if o == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func corsOriginAllowed(allowed []string, origin string) bool {
+	for _, o := range allowed {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_AllowedOriginGetsHeaders(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://allowed.example.com")
+	router := setupTestRouter()
+	router.Use(CORS())
+	router.GET("/solve", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest("GET", "/solve", nil)
+	req.Header.Set("Origin", "https://allowed.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://allowed.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_DisallowedOriginGetsNoHeaders(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://allowed.example.com")
+	router := setupTestRouter()
+	router.Use(CORS())
+	router.GET("/solve", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest("GET", "/solve", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_WildcardEchoesStarAndShortCircuitsPreflight(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	router := setupTestRouter()
+	router.Use(CORS())
+	router.POST("/solve", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest(http.MethodOptions, "/solve", nil)
+	req.Header.Set("Origin", "https://anything.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the Prometheus collectors used to instrument HTTP requests
+// and calls to the upstream Hopfield service. Unlike the old package-level
+// RequestMetrics, every collector here is safe for concurrent use and is
+// registered against an explicit prometheus.Registerer so tests can create
+// an isolated registry instead of polluting the global one.
+type Metrics struct {
+	requestDuration  *prometheus.HistogramVec
+	requestsTotal    *prometheus.CounterVec
+	requestErrors    *prometheus.CounterVec
+	requestsInFlight prometheus.Gauge
+
+	hopfieldCalls    *prometheus.CounterVec
+	hopfieldDuration prometheus.Histogram
+	hopfieldTimeouts prometheus.Counter
+	hopfieldRetries  prometheus.Counter
+
+	breakerTransitions *prometheus.CounterVec
+	breakerState       prometheus.Gauge
+
+	solveRequests *prometheus.CounterVec
+
+	registerer prometheus.Registerer
+}
+
+// NewMetrics creates the collectors and registers them against reg. Pass
+// prometheus.NewRegistry() in tests so each test gets its own collectors
+// instead of re-registering against the global default registry.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "path", "status"}),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests.",
+		}, []string{"method", "path", "status"}),
+		requestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "Total number of HTTP requests that completed with a 4xx/5xx status.",
+		}, []string{"method", "path", "status"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		}),
+		hopfieldCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "hopfield_upstream_calls_total",
+			Help: "Total number of calls made to the upstream Hopfield service, by outcome.",
+		}, []string{"outcome"}),
+		hopfieldDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "hopfield_upstream_call_duration_seconds",
+			Help:    "Latency of calls to the upstream Hopfield service in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		hopfieldTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "hopfield_upstream_timeouts_total",
+			Help: "Total number of upstream Hopfield calls that failed because the context deadline was exceeded.",
+		}),
+		hopfieldRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "hopfield_upstream_retries_total",
+			Help: "Total number of retried calls to the upstream Hopfield service.",
+		}),
+		breakerTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "hopfield_breaker_transitions_total",
+			Help: "Total number of circuit breaker state transitions, by origin and destination state.",
+		}, []string{"from", "to"}),
+		breakerState: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "hopfield_breaker_state",
+			Help: "Current circuit breaker state protecting the Hopfield upstream: 0=closed, 1=half-open, 2=open.",
+		}),
+		solveRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "solve_requests_total",
+			Help: "Total number of assignment problems solved, by route, outcome and cost matrix size.",
+		}, []string{"route", "status", "matrix_size_bucket"}),
+		registerer: reg,
+	}
+
+	reg.MustRegister(
+		m.requestDuration,
+		m.requestsTotal,
+		m.requestErrors,
+		m.requestsInFlight,
+		m.hopfieldCalls,
+		m.hopfieldDuration,
+		m.hopfieldTimeouts,
+		m.hopfieldRetries,
+		m.breakerTransitions,
+		m.breakerState,
+		m.solveRequests,
+	)
+
+	return m
+}
+
+// Middleware returns a gin.HandlerFunc that records request counts, latency
+// and in-flight requests for every request it observes.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.requestsInFlight.Inc()
+		defer m.requestsInFlight.Dec()
+
+		c.Next()
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		labels := prometheus.Labels{
+			"method": c.Request.Method,
+			"path":   path,
+			"status": strconv.Itoa(c.Writer.Status()),
+		}
+		m.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+		m.requestsTotal.With(labels).Inc()
+		if c.Writer.Status() >= 400 {
+			m.requestErrors.With(labels).Inc()
+		}
+	}
+}
+
+// ObserveHopfieldCall records the outcome and latency of a single call to
+// the upstream Hopfield service. timedOut should be true when the call
+// failed because its context deadline was exceeded, so operators can tell
+// timeouts apart from other upstream failures.
+func (m *Metrics) ObserveHopfieldCall(d time.Duration, err error, timedOut bool) {
+	m.hopfieldDuration.Observe(d.Seconds())
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		if timedOut {
+			m.hopfieldTimeouts.Inc()
+		}
+	}
+	m.hopfieldCalls.With(prometheus.Labels{"outcome": outcome}).Inc()
+}
+
+// ObserveHopfieldRetry records a retried call to the upstream Hopfield
+// service.
+func (m *Metrics) ObserveHopfieldRetry() {
+	m.hopfieldRetries.Inc()
+}
+
+// ObserveBreakerStateChange records a circuit breaker state transition
+// and updates the current-state gauge. from and to are one of "closed",
+// "half-open" or "open".
+func (m *Metrics) ObserveBreakerStateChange(from, to string) {
+	m.breakerTransitions.With(prometheus.Labels{"from": from, "to": to}).Inc()
+	m.breakerState.Set(breakerStateValue(to))
+}
+
+func breakerStateValue(state string) float64 {
+	switch state {
+	case "open":
+		return 2
+	case "half-open":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ObserveSolveRequest records the outcome of solving one assignment
+// problem on the given route ("solve" or "solve_batch"), bucketed by the
+// size of its cost matrix so operators can tell whether failures or
+// latency correlate with problem size.
+func (m *Metrics) ObserveSolveRequest(route, status string, matrixSize int) {
+	m.solveRequests.With(prometheus.Labels{
+		"route":              route,
+		"status":             status,
+		"matrix_size_bucket": matrixSizeBucket(matrixSize),
+	}).Inc()
+}
+
+// matrixSizeBucket groups a cost matrix's dimension N into one of a
+// small number of buckets, keeping the matrix_size_bucket cardinality
+// bounded regardless of how large N gets in practice.
+func matrixSizeBucket(n int) string {
+	switch {
+	case n <= 4:
+		return "1-4"
+	case n <= 16:
+		return "5-16"
+	case n <= 64:
+		return "17-64"
+	default:
+		return "65+"
+	}
+}
+
+// RegisterJobQueueDepth wires a gauge that reports the current depth of
+// the async job queue, evaluating depthFn on every scrape rather than
+// requiring callers to push updates whenever the queue changes.
+func (m *Metrics) RegisterJobQueueDepth(depthFn func() float64) {
+	m.registerer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "job_queue_depth",
+		Help: "Current number of jobs waiting in the async job queue for a free worker.",
+	}, depthFn))
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Middleware_RecordsRequestCountLatencyAndErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	router.GET("/boom", func(c *gin.Context) { c.JSON(http.StatusInternalServerError, gin.H{"ok": false}) })
+
+	for i := 0; i < 3; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	}
+	for i := 0; i < 2; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+	}
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(metrics.requestsTotal.WithLabelValues("GET", "/ok", "200")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.requestsTotal.WithLabelValues("GET", "/boom", "500")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.requestErrors.WithLabelValues("GET", "/boom", "500")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.requestErrors.WithLabelValues("GET", "/ok", "200")))
+
+	// Both label combinations should have recorded at least one latency
+	// observation, i.e. the histogram's buckets are actually populated.
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.requestDuration))
+}
+
+func TestMetrics_ObserveSolveRequest_BucketsByMatrixSize(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+
+	metrics.ObserveSolveRequest("solve", "success", 3)
+	metrics.ObserveSolveRequest("solve", "error", 3)
+	metrics.ObserveSolveRequest("solve_batch", "success", 40)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.solveRequests.WithLabelValues("solve", "success", "1-4")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.solveRequests.WithLabelValues("solve", "error", "1-4")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.solveRequests.WithLabelValues("solve_batch", "success", "17-64")))
+}
+
+func TestMatrixSizeBucket(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{1, "1-4"},
+		{4, "1-4"},
+		{5, "5-16"},
+		{16, "5-16"},
+		{17, "17-64"},
+		{64, "17-64"},
+		{65, "65+"},
+		{1000, "65+"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, matrixSizeBucket(tt.n))
+	}
+}
+
+func TestMetrics_RegisterJobQueueDepth_ReflectsCallback(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+
+	depth := 7
+	metrics.RegisterJobQueueDepth(func() float64 { return float64(depth) })
+
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+
+	var found bool
+	for _, fam := range families {
+		if fam.GetName() == "job_queue_depth" {
+			found = true
+			assert.Equal(t, float64(depth), fam.GetMetric()[0].GetGauge().GetValue())
+		}
+	}
+	assert.True(t, found, "expected job_queue_depth to be registered")
+}
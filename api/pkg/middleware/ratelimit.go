@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"hopfield-assignment-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// bucketTTL and evictInterval bound how long an idle per-key token bucket
+// is kept around: without eviction, a long-running process accumulates one
+// bucket per distinct API key/IP it has ever seen.
+const (
+	bucketTTL     = 10 * time.Minute
+	evictInterval = 5 * time.Minute
+)
+
+// RateLimitConfig configures the token bucket applied per key for a
+// single route.
+type RateLimitConfig struct {
+	// RPS is the sustained number of requests per second a single key may
+	// make.
+	RPS float64
+	// Burst is the maximum number of requests a key may make instantly
+	// before RPS throttling kicks in.
+	Burst int
+}
+
+// RateLimiter enforces a per-key token-bucket rate limit. Keys are the
+// authenticated subject, falling back to the client IP when the request
+// carries no identity. A background goroutine evicts buckets that have
+// gone unused for bucketTTL so memory doesn't grow unbounded with the
+// number of distinct keys seen over the process lifetime.
+type RateLimiter struct {
+	cfg RateLimitConfig
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// NewRateLimiter creates a RateLimiter enforcing cfg and starts its
+// eviction goroutine. The goroutine stops when ctx is done.
+func NewRateLimiter(ctx context.Context, cfg RateLimitConfig) *RateLimiter {
+	rl := &RateLimiter{
+		cfg:     cfg,
+		buckets: make(map[string]*tokenBucket),
+	}
+	go rl.evictIdleBuckets(ctx)
+	return rl
+}
+
+func (rl *RateLimiter) evictIdleBuckets(ctx context.Context) {
+	ticker := time.NewTicker(evictInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rl.mu.Lock()
+			for key, b := range rl.buckets {
+				if time.Since(b.lastUsed) > bucketTTL {
+					delete(rl.buckets, key)
+				}
+			}
+			rl.mu.Unlock()
+		}
+	}
+}
+
+func (rl *RateLimiter) bucketFor(key string) *tokenBucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &tokenBucket{limiter: rate.NewLimiter(rate.Limit(rl.cfg.RPS), rl.cfg.Burst)}
+		rl.buckets[key] = b
+	}
+	b.lastUsed = time.Now()
+	return b
+}
+
+// Middleware returns a gin.HandlerFunc that allows or rejects the request
+// based on the live state of the caller's token bucket. A rejected
+// request gets a 429; every response (allowed or not) carries
+// X-RateLimit-Limit and X-RateLimit-Remaining reflecting the bucket's
+// state at the time of the check. Retry-After and X-RateLimit-Reset are
+// set only on a request rejected because its token is not yet available;
+// a request that can never be satisfied (e.g. Burst 0) gets neither.
+func (rl *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bucket := rl.bucketFor(rateLimitKey(c))
+
+		reservation := bucket.limiter.ReserveN(time.Now(), 1)
+		if !reservation.OK() {
+			// Burst is 0 or the request can never be satisfied; reject
+			// without reserving a token that would never free up.
+			c.Header("X-RateLimit-Limit", strconv.Itoa(rl.cfg.Burst))
+			c.Header("X-RateLimit-Remaining", "0")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.APIResponse{
+				Success: false,
+				Error:   "rate limit exceeded",
+			})
+			return
+		}
+
+		delay := reservation.DelayFrom(time.Now())
+		if delay > 0 {
+			reservation.Cancel()
+
+			retryAfter := int(delay.Round(time.Second).Seconds())
+			c.Header("X-RateLimit-Limit", strconv.Itoa(rl.cfg.Burst))
+			c.Header("X-RateLimit-Remaining", "0")
+			c.Header("X-RateLimit-Reset", strconv.Itoa(retryAfter))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.APIResponse{
+				Success: false,
+				Error:   "rate limit exceeded",
+			})
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(rl.cfg.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(bucket.limiter.Tokens())))
+		c.Next()
+	}
+}
+
+// rateLimitKey returns the authenticated identity's subject if the auth
+// chain has already populated the context, falling back to the client IP
+// for unauthenticated or dev-mode requests.
+func rateLimitKey(c *gin.Context) string {
+	if subject := c.GetString("subject"); subject != "" {
+		return subject
+	}
+	return c.ClientIP()
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func TestRateLimiter_AllowsUpToBurstThenRejects(t *testing.T) {
+	router := setupTestRouter()
+	limiter := NewRateLimiter(context.Background(), RateLimitConfig{RPS: 1, Burst: 2})
+	router.Use(limiter.Middleware())
+	router.POST("/solve", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/solve", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	req := httptest.NewRequest("POST", "/solve", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, w.Header().Get("Retry-After"), w.Header().Get("X-RateLimit-Reset"))
+}
+
+func TestRateLimiter_KeyedByAuthenticatedSubject(t *testing.T) {
+	router := setupTestRouter()
+	limiter := NewRateLimiter(context.Background(), RateLimitConfig{RPS: 1, Burst: 1})
+	router.Use(func(c *gin.Context) {
+		c.Set("subject", c.GetHeader("X-Subject"))
+		c.Next()
+	})
+	router.Use(limiter.Middleware())
+	router.POST("/solve/batch", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	// "alice" exhausts her single token.
+	req := httptest.NewRequest("POST", "/solve/batch", nil)
+	req.Header.Set("X-Subject", "alice")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("POST", "/solve/batch", nil)
+	req.Header.Set("X-Subject", "alice")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// "bob" has his own untouched bucket.
+	req = httptest.NewRequest("POST", "/solve/batch", nil)
+	req.Header.Set("X-Subject", "bob")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimiter_FallsBackToClientIPWhenUnauthenticated(t *testing.T) {
+	router := setupTestRouter()
+	limiter := NewRateLimiter(context.Background(), RateLimitConfig{RPS: 1, Burst: 1})
+	router.Use(limiter.Middleware())
+	router.POST("/solve", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	req := httptest.NewRequest("POST", "/solve", nil)
+	req.RemoteAddr = "203.0.113.10:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("POST", "/solve", nil)
+	req.RemoteAddr = "203.0.113.10:5678"
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
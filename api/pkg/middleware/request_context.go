@@ -43,21 +43,32 @@ func StructuredLogging(logger *logrus.Logger) gin.HandlerFunc {
 
 		// Log with structured fields
 		fields := logrus.Fields{
-			"request_id":  requestID,
-			"method":      c.Request.Method,
-			"path":        path,
-			"query":       raw,
-			"status":      c.Writer.Status(),
-			"latency_ms":  latency.Milliseconds(),
-			"client_ip":   c.ClientIP(),
-			"user_agent":  c.Request.UserAgent(),
-			"error":       c.Errors.String(),
+			"request_id": requestID,
+			"method":     c.Request.Method,
+			"path":       path,
+			"query":      raw,
+			"status":     c.Writer.Status(),
+			"latency_ms": latency.Milliseconds(),
+			"client_ip":  c.ClientIP(),
+			"user_agent": c.Request.UserAgent(),
+			"error":      c.Errors.String(),
 		}
 
 		// Add authentication info if available
 		if authenticated, exists := c.Get("authenticated"); exists {
 			fields["authenticated"] = authenticated
 		}
+		if subject, exists := c.Get("subject"); exists {
+			fields["subject"] = subject
+		}
+		if scopes, exists := c.Get("scopes"); exists {
+			fields["scopes"] = scopes
+		}
+
+		// Add the mTLS client certificate subject if one was presented
+		if subject, exists := c.Get("client_cert_subject"); exists {
+			fields["client_cert_subject"] = subject
+		}
 
 		// Log based on status code
 		statusCode := c.Writer.Status()
@@ -70,40 +81,3 @@ func StructuredLogging(logger *logrus.Logger) gin.HandlerFunc {
 		}
 	}
 }
-
-// RequestMetrics middleware tracks request metrics
-type RequestMetrics struct {
-	TotalRequests   int64
-	ErrorCount      int64
-	AvgLatency      time.Duration
-	MaxLatency      time.Duration
-}
-
-var metrics = &RequestMetrics{}
-
-func Metrics() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		start := time.Now()
-
-		c.Next()
-
-		latency := time.Since(start)
-		metrics.TotalRequests++
-
-		if c.Writer.Status() >= 400 {
-			metrics.ErrorCount++
-		}
-
-		if latency > metrics.MaxLatency {
-			metrics.MaxLatency = latency
-		}
-
-		// Simple moving average
-		metrics.AvgLatency = (metrics.AvgLatency + latency) / 2
-	}
-}
-
-// GetMetrics returns current metrics
-func GetMetrics() *RequestMetrics {
-	return metrics
-}
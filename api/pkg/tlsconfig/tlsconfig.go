@@ -0,0 +1,122 @@
+// Package tlsconfig assembles *tls.Config values for the server and for
+// the outbound Hopfield service client from file-based certificate
+// material, so main.go and the hopfield client don't have to duplicate
+// x509 loading logic.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// ServerConfig describes the certificate material for terminating TLS on
+// the API's own listener.
+type ServerConfig struct {
+	// CertFile and KeyFile are the server's own certificate and private
+	// key, in PEM format. Both are required to build a server config.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile, if set, enables mTLS: client certificates are
+	// required and verified against the CA bundle at this path.
+	ClientCAFile string
+}
+
+// Build loads the configured certificate material and returns a
+// *tls.Config suitable for http.Server.TLSConfig. It returns an error if
+// CertFile/KeyFile cannot be loaded, or if ClientCAFile is set but cannot
+// be read or contains no valid certificates.
+func (c ServerConfig) Build() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading server certificate: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if c.ClientCAFile == "" {
+		return tlsCfg, nil
+	}
+
+	pool, err := loadCAPool(c.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading client CA bundle: %w", err)
+	}
+	tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	tlsCfg.ClientCAs = pool
+
+	return tlsCfg, nil
+}
+
+// ClientConfig describes the certificate material for the outbound HTTP
+// client that talks to the upstream Hopfield service.
+type ClientConfig struct {
+	// CAFile, if set, is a PEM CA bundle used to verify the Hopfield
+	// service's certificate instead of the system trust store.
+	CAFile string
+
+	// CertFile and KeyFile, if both set, present a client certificate to
+	// the Hopfield service for mTLS.
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables certificate verification entirely. It
+	// exists for local development only and must never be set in
+	// production.
+	InsecureSkipVerify bool
+}
+
+// IsZero reports whether c has no TLS customization at all, meaning the
+// caller needs no custom *tls.Config and can leave Go's defaults in place.
+func (c ClientConfig) IsZero() bool {
+	return c.CAFile == "" && c.CertFile == "" && c.KeyFile == "" && !c.InsecureSkipVerify
+}
+
+// Build assembles a *tls.Config for the outbound Hopfield client. It
+// returns an error if CAFile is set but cannot be loaded, if exactly one
+// of CertFile/KeyFile is set, or if the client key pair cannot be
+// loaded.
+func (c ClientConfig) Build() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCAPool(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading Hopfield CA bundle: %w", err)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	switch {
+	case c.CertFile != "" && c.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading Hopfield client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case c.CertFile != "" || c.KeyFile != "":
+		return nil, fmt.Errorf("both a client certificate and key are required for mTLS, got cert=%q key=%q", c.CertFile, c.KeyFile)
+	}
+
+	return tlsCfg, nil
+}
+
+func loadCAPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in %s", path)
+	}
+
+	return pool, nil
+}
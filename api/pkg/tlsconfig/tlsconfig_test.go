@@ -0,0 +1,137 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateCert writes a self-signed certificate and key PEM pair under
+// dir and returns their paths.
+func generateCert(t *testing.T, dir, name string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+
+	writePEM(t, certPath, "CERTIFICATE", der)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+
+	return certPath, keyPath
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}))
+}
+
+func TestServerConfig_Build(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateCert(t, dir, "server")
+	caPath, _ := generateCert(t, dir, "ca")
+
+	t.Run("cert and key only", func(t *testing.T) {
+		cfg := ServerConfig{CertFile: certPath, KeyFile: keyPath}
+		tlsCfg, err := cfg.Build()
+		assert.NoError(t, err)
+		assert.Len(t, tlsCfg.Certificates, 1)
+		assert.Nil(t, tlsCfg.ClientCAs)
+	})
+
+	t.Run("mTLS with client CA", func(t *testing.T) {
+		cfg := ServerConfig{CertFile: certPath, KeyFile: keyPath, ClientCAFile: caPath}
+		tlsCfg, err := cfg.Build()
+		assert.NoError(t, err)
+		assert.NotNil(t, tlsCfg.ClientCAs)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+	})
+
+	t.Run("missing cert file", func(t *testing.T) {
+		cfg := ServerConfig{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyPath}
+		_, err := cfg.Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing client CA file", func(t *testing.T) {
+		cfg := ServerConfig{CertFile: certPath, KeyFile: keyPath, ClientCAFile: filepath.Join(dir, "missing-ca.crt")}
+		_, err := cfg.Build()
+		assert.Error(t, err)
+	})
+}
+
+func TestClientConfig_Build(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateCert(t, dir, "client")
+	caPath, _ := generateCert(t, dir, "ca")
+
+	t.Run("zero value has no TLS customization", func(t *testing.T) {
+		assert.True(t, ClientConfig{}.IsZero())
+		assert.False(t, ClientConfig{CAFile: caPath}.IsZero())
+	})
+
+	t.Run("CA only", func(t *testing.T) {
+		cfg := ClientConfig{CAFile: caPath}
+		tlsCfg, err := cfg.Build()
+		assert.NoError(t, err)
+		assert.NotNil(t, tlsCfg.RootCAs)
+		assert.Empty(t, tlsCfg.Certificates)
+	})
+
+	t.Run("mTLS with client cert", func(t *testing.T) {
+		cfg := ClientConfig{CAFile: caPath, CertFile: certPath, KeyFile: keyPath}
+		tlsCfg, err := cfg.Build()
+		assert.NoError(t, err)
+		assert.Len(t, tlsCfg.Certificates, 1)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		cfg := ClientConfig{InsecureSkipVerify: true}
+		tlsCfg, err := cfg.Build()
+		assert.NoError(t, err)
+		assert.True(t, tlsCfg.InsecureSkipVerify)
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		cfg := ClientConfig{CAFile: filepath.Join(dir, "missing-ca.crt")}
+		_, err := cfg.Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("cert without key is an error", func(t *testing.T) {
+		cfg := ClientConfig{CertFile: certPath}
+		_, err := cfg.Build()
+		assert.Error(t, err)
+	})
+}